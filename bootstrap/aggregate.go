@@ -0,0 +1,91 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ax-lab/byte/diff"
+)
+
+// AggregateSignature summarizes a failing test's diff blocks into a
+// coarse, human-readable description of the *kind* of change, so many
+// tests that failed the same way (e.g. a formatting change rippling
+// through the whole suite) collapse into one report line instead of
+// repeating the same diff over and over.
+func AggregateSignature(blocks []diff.DiffBlock) string {
+	if len(blocks) == 0 {
+		return "no diff available"
+	}
+	stats := diff.Count(blocks)
+	switch {
+	case stats.DeleteLines == 0 && stats.InsertLines == 0:
+		return "no textual difference"
+	case stats.DeleteLines == stats.InsertLines && stats.DeleteBlocks == 1 && stats.InsertBlocks == 1:
+		return fmt.Sprintf("%d line(s) changed", stats.DeleteLines)
+	case stats.DeleteLines == 0:
+		return fmt.Sprintf("%d line(s) added", stats.InsertLines)
+	case stats.InsertLines == 0:
+		return fmt.Sprintf("%d line(s) removed", stats.DeleteLines)
+	default:
+		return fmt.Sprintf("%d line(s) removed, %d line(s) added", stats.DeleteLines, stats.InsertLines)
+	}
+}
+
+// signatureFor extracts an AggregateSignature from err's diff blocks
+// when it carries any, falling back to err's own message for failures
+// that aren't a line-level mismatch (e.g. a run error).
+func signatureFor(err error) string {
+	switch e := err.(type) {
+	case *MismatchError:
+		return AggregateSignature(e.Blocks)
+	case *JSONMismatchError:
+		return AggregateSignature(e.Blocks)
+	default:
+		return err.Error()
+	}
+}
+
+// AggregateEntry is one row of an aggregated failure report: a
+// signature and the names of every test that failed with it.
+type AggregateEntry struct {
+	Signature string
+	Tests     []string
+}
+
+// Aggregate groups results's failing tests by AggregateSignature,
+// returning entries sorted by descending test count (ties broken by
+// signature) so the most common failure mode is reported first.
+func Aggregate(results []ScriptTest) []AggregateEntry {
+	bySignature := map[string][]string{}
+	for _, test := range results {
+		if test.Passed || test.Skipped {
+			continue
+		}
+		sig := signatureFor(test.Err)
+		bySignature[sig] = append(bySignature[sig], test.Name)
+	}
+
+	entries := make([]AggregateEntry, 0, len(bySignature))
+	for sig, tests := range bySignature {
+		sort.Strings(tests)
+		entries = append(entries, AggregateEntry{Signature: sig, Tests: tests})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].Tests) != len(entries[j].Tests) {
+			return len(entries[i].Tests) > len(entries[j].Tests)
+		}
+		return entries[i].Signature < entries[j].Signature
+	})
+	return entries
+}
+
+// WriteAggregate prints results's failures grouped by signature to w,
+// most common failure mode first.
+func WriteAggregate(w io.Writer, results []ScriptTest) {
+	for _, entry := range Aggregate(results) {
+		fmt.Fprintf(w, "%d test(s): %s\n", len(entry.Tests), entry.Signature)
+		fmt.Fprintf(w, "  %s\n", strings.Join(entry.Tests, ", "))
+	}
+}
@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ax-lab/byte/diff"
+)
+
+func TestAggregateSignatureDescribesKindOfChange(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []string
+		want string
+	}{
+		{"changed", []string{"x\n"}, []string{"y\n"}, "1 line(s) changed"},
+		{"added", []string{"x\n"}, []string{"x\n", "y\n"}, "1 line(s) added"},
+		{"removed", []string{"x\n", "y\n"}, []string{"x\n"}, "1 line(s) removed"},
+		{"identical", []string{"x\n"}, []string{"x\n"}, "no textual difference"},
+	}
+	for _, c := range cases {
+		got := AggregateSignature(diff.Compare(c.a, c.b))
+		if got != c.want {
+			t.Errorf("%s: AggregateSignature = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestAggregateGroupsBySignatureMostCommonFirst(t *testing.T) {
+	results := []ScriptTest{
+		{Name: "a", Passed: false, Err: &MismatchError{Test: "a", Blocks: diff.Compare([]string{"x\n"}, []string{"y\n"})}},
+		{Name: "b", Passed: false, Err: &MismatchError{Test: "b", Blocks: diff.Compare([]string{"x\n"}, []string{"y\n"})}},
+		{Name: "c", Passed: false, Err: &MismatchError{Test: "c", Blocks: diff.Compare([]string{"x\n"}, []string{"x\n", "y\n"})}},
+		{Name: "d", Passed: true},
+		{Name: "e", Skipped: true},
+	}
+
+	entries := Aggregate(results)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].Signature != "1 line(s) changed" || len(entries[0].Tests) != 2 {
+		t.Fatalf("most common entry = %+v", entries[0])
+	}
+
+	var buf strings.Builder
+	WriteAggregate(&buf, results)
+	if !strings.Contains(buf.String(), "2 test(s): 1 line(s) changed") {
+		t.Fatalf("WriteAggregate output missing expected summary line:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeArtifacts saves test's captured result to
+// <artifactsDir>/<relative path of test.Dir under rootDir>/<test.Name>/,
+// as separate stdout, stderr, and exitcode files, for post-mortem
+// debugging after the run has finished. Each run overwrites whatever
+// artifacts an earlier run left for the same test; nothing is appended
+// or versioned.
+func writeArtifacts(artifactsDir, rootDir string, test *ScriptTest, result RunResult) error {
+	rel := Relative(rootDir, test.Dir)
+	if rel == "" {
+		rel = test.Dir
+	}
+	dir := filepath.Join(artifactsDir, rel, test.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		"stdout":   result.Stdout,
+		"stderr":   result.Stderr,
+		"exitcode": fmt.Sprintf("%d\n", result.ExitCode),
+	}
+	for name, content := range files {
+		if err := writeFileAtomic(filepath.Join(dir, name), []byte(content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
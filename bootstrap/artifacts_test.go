@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteArtifactsLayout(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	artifacts := t.TempDir()
+
+	test := &ScriptTest{Name: "example", Dir: sub}
+	result := RunResult{Stdout: "out\n", Stderr: "err\n", ExitCode: 3}
+
+	if err := writeArtifacts(artifacts, root, test, result); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(artifacts, "sub", "example")
+	for name, want := range map[string]string{"stdout": "out\n", "stderr": "err\n", "exitcode": "3\n"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("reading %s: %v", name, err)
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", name, data, want)
+		}
+	}
+}
+
+func TestWriteArtifactsOverwritesPreviousRun(t *testing.T) {
+	root := t.TempDir()
+	artifacts := t.TempDir()
+	test := &ScriptTest{Name: "example", Dir: root}
+
+	if err := writeArtifacts(artifacts, root, test, RunResult{Stdout: "first\n"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeArtifacts(artifacts, root, test, RunResult{Stdout: "second\n"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(artifacts, "example", "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "second\n" {
+		t.Fatalf("stdout = %q, want the latest run's output", data)
+	}
+}
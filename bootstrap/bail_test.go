@@ -0,0 +1,88 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// writeFailingFixture writes a `.byte` script paired with a `.out` file
+// that can never match its output, so running it under BinPath "true"
+// (which always exits 0 with empty stdout) always fails.
+func writeFailingFixture(t *testing.T, dir, name string) {
+	t.Helper()
+	writeScript(t, dir, name+".byte", "")
+	writeScript(t, dir, name+".out", "this never matches\n")
+}
+
+func TestRunTestsBailsAfterReachingTheFailureThreshold(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		writeFailingFixture(t, dir, name)
+	}
+
+	summary := RunTests(TestOptions{Dir: dir, BinPath: "true", Bail: 2})
+
+	if !summary.Bailed {
+		t.Fatal("expected Bailed to be set")
+	}
+	if summary.Failed != 2 {
+		t.Fatalf("Failed = %d, want 2", summary.Failed)
+	}
+	if len(summary.Results) != 2 {
+		t.Fatalf("len(Results) = %d, want 2, since the rest should be left unrun", len(summary.Results))
+	}
+}
+
+func TestRunTestsWithoutBailRunsEveryFailingTest(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b", "c"} {
+		writeFailingFixture(t, dir, name)
+	}
+
+	summary := RunTests(TestOptions{Dir: dir, BinPath: "true"})
+
+	if summary.Bailed {
+		t.Fatal("expected Bailed to be unset when Bail is 0")
+	}
+	if summary.Failed != 3 {
+		t.Fatalf("Failed = %d, want 3", summary.Failed)
+	}
+	if len(summary.Results) != 3 {
+		t.Fatalf("len(Results) = %d, want 3", len(summary.Results))
+	}
+}
+
+func TestRunTestsWithBailHigherThanFailureCountNeverBails(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		writeFailingFixture(t, dir, name)
+	}
+
+	summary := RunTests(TestOptions{Dir: dir, BinPath: "true", Bail: 10})
+
+	if summary.Bailed {
+		t.Fatal("expected Bailed to be unset when the threshold is never reached")
+	}
+	if len(summary.Results) != 2 {
+		t.Fatalf("len(Results) = %d, want 2", len(summary.Results))
+	}
+}
+
+func TestRunTestsBailDoesNotCountXFailFailures(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "x.byte", "# xfail\n")
+	writeScript(t, dir, "x.out", "this never matches\n")
+	writeFailingFixture(t, dir, "y")
+
+	summary := RunTests(TestOptions{Dir: filepath.Clean(dir), BinPath: "true", Bail: 1})
+
+	if summary.XFailed != 1 {
+		t.Fatalf("XFailed = %d, want 1", summary.XFailed)
+	}
+	if !summary.Bailed {
+		t.Fatal("expected Bailed to be set once y's genuine failure reached the threshold")
+	}
+	if len(summary.Results) != 2 {
+		t.Fatalf("len(Results) = %d, want 2 (the xfail test plus the one that triggered bail)", len(summary.Results))
+	}
+}
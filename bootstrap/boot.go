@@ -0,0 +1,179 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cargoTimeoutEnv, when set to a duration string (e.g. "2m"), bounds
+// how long `cargo build` is allowed to run before Boot kills it and
+// retries once. Unset by default so local interactive builds never hit
+// a surprise timeout; CI sets it to avoid hanging on a stuck build.
+const cargoTimeoutEnv = "CARGO_TIMEOUT"
+
+// sourceExts lists the file extensions considered cargo sources when
+// checking whether a compiled binary is stale.
+var sourceExts = map[string]bool{
+	".rs":   true,
+	".toml": true,
+}
+
+// newestSourceFile walks dir and returns the path and modification time
+// of the most recently changed source file under it, skipping the
+// cargo "target" build directory.
+func newestSourceFile(dir string) (path string, mtime time.Time, err error) {
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == "target" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !sourceExts[filepath.Ext(p)] {
+			return nil
+		}
+		if info.ModTime().After(mtime) {
+			path = p
+			mtime = info.ModTime()
+		}
+		return nil
+	})
+	return path, mtime, err
+}
+
+// skipBuildEnv, when set to any non-empty value, skips Boot's rebuild
+// check entirely: the existing binary at binPath is used as-is, even if
+// it's missing or stale. Meant for CI that builds the workspace as its
+// own separate step and wants test runs to fail fast on a missing
+// binary rather than silently rebuilding it. BootOptions.Skip does the
+// same from Go code.
+const skipBuildEnv = "SKIP_BUILD"
+
+// BuildConfig names the command Boot runs to (re)build the interpreter,
+// so a workspace with an unusual layout or build tool isn't stuck with
+// `cargo build`.
+type BuildConfig struct {
+	// Command is the executable to run, e.g. "cargo". Defaults to
+	// "cargo" when empty.
+	Command string
+	// Args are the arguments passed to Command, e.g. ["build"].
+	// Defaults to ["build"] when nil.
+	Args []string
+}
+
+// withDefaults fills in cfg's empty fields with DefaultBuildConfig's.
+func (cfg BuildConfig) withDefaults() BuildConfig {
+	def := DefaultBuildConfig()
+	if cfg.Command == "" {
+		cfg.Command = def.Command
+	}
+	if cfg.Args == nil {
+		cfg.Args = def.Args
+	}
+	return cfg
+}
+
+// DefaultBuildConfig returns the historical `cargo build` invocation.
+func DefaultBuildConfig() BuildConfig {
+	return BuildConfig{Command: "cargo", Args: []string{"build"}}
+}
+
+// BootOptions configures a BootWithOptions call.
+type BootOptions struct {
+	// Skip, if true, returns binPath unchanged without checking
+	// staleness or building anything. Also settable via skipBuildEnv.
+	Skip bool
+	// Build overrides the command used to (re)build the interpreter.
+	// The zero value runs DefaultBuildConfig's `cargo build`.
+	Build BuildConfig
+}
+
+// Boot is BootWithOptions with the default options: `cargo build`, run
+// whenever the binary is missing or stale, unless skipBuildEnv is set.
+func Boot(binPath, cargoDir string) (string, error) {
+	return BootWithOptions(binPath, cargoDir, BootOptions{})
+}
+
+// BootWithOptions ensures the byte interpreter binary at binPath is up
+// to date, rebuilding it from the cargo workspace at cargoDir with
+// opts.Build (or `cargo build` by default) if it's missing or older
+// than its sources. It returns binPath for convenience, so callers can
+// chain it straight into RunTests.
+func BootWithOptions(binPath, cargoDir string, opts BootOptions) (string, error) {
+	if opts.Skip || os.Getenv(skipBuildEnv) != "" {
+		return binPath, nil
+	}
+
+	runner := NewRunner(binPath, cargoDir)
+
+	stale, err := runner.IsStale()
+	if err != nil {
+		return "", err
+	}
+	if !stale {
+		return binPath, nil
+	}
+
+	if err := cargoBuild(cargoDir, opts.Build.withDefaults()); err != nil {
+		return "", err
+	}
+	return binPath, nil
+}
+
+// cargoBuild runs build.Command with build.Args in cargoDir. If
+// cargoTimeoutEnv is set, it bounds the build with that timeout,
+// killing the process tree and retrying once on a timeout or a
+// transient "waiting for file lock" failure (cargo serializes builds
+// against the same target directory with a lock file, which can
+// briefly contend under CI).
+func cargoBuild(cargoDir string, build BuildConfig) error {
+	timeout, ok := cargoBuildTimeout()
+	if !ok {
+		result, err := RunWith(build.Command, build.Args, RunOptions{Dir: cargoDir, Echo: true})
+		if err == nil && result.ExitCode != 0 {
+			err = fmt.Errorf("%s %s: exit status %d", build.Command, strings.Join(build.Args, " "), result.ExitCode)
+		}
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		result, err := RunContext(ctx, build.Command, build.Args, RunOptions{Dir: cargoDir, Echo: true})
+		cancel()
+		if err == nil && result.ExitCode != 0 {
+			err = fmt.Errorf("exit status %d", result.ExitCode)
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		transient := err == context.DeadlineExceeded || strings.Contains(result.Stderr, "waiting for file lock")
+		if attempt == 1 && transient {
+			fmt.Fprintf(os.Stderr, "%s %s: retrying after %v\n%s\n", build.Command, strings.Join(build.Args, " "), err, lastLines(result.Stderr, 5))
+			continue
+		}
+		return fmt.Errorf("%s %s: %w", build.Command, strings.Join(build.Args, " "), err)
+	}
+}
+
+// cargoBuildTimeout parses cargoTimeoutEnv, reporting ok=false when it's
+// unset or invalid (treated the same as unset: no timeout).
+func cargoBuildTimeout() (time.Duration, bool) {
+	s := os.Getenv(cargoTimeoutEnv)
+	if s == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
@@ -0,0 +1,69 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBootWithOptionsSkipNeverChecksStaleness(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "missing-binary")
+
+	got, err := BootWithOptions(binPath, dir, BootOptions{Skip: true})
+	if err != nil {
+		t.Fatalf("BootWithOptions(Skip) = %v, want nil error even for a missing binary", err)
+	}
+	if got != binPath {
+		t.Fatalf("BootWithOptions(Skip) = %q, want %q unchanged", got, binPath)
+	}
+}
+
+func TestBootWithOptionsSkipBuildEnvSkipsToo(t *testing.T) {
+	defer os.Unsetenv(skipBuildEnv)
+	os.Setenv(skipBuildEnv, "1")
+
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "missing-binary")
+
+	got, err := BootWithOptions(binPath, dir, BootOptions{})
+	if err != nil {
+		t.Fatalf("BootWithOptions(%s=1) = %v, want nil error", skipBuildEnv, err)
+	}
+	if got != binPath {
+		t.Fatalf("BootWithOptions(%s=1) = %q, want %q unchanged", skipBuildEnv, got, binPath)
+	}
+}
+
+func TestBuildConfigWithDefaultsFillsEmptyFields(t *testing.T) {
+	got := BuildConfig{}.withDefaults()
+	want := DefaultBuildConfig()
+	if got.Command != want.Command || len(got.Args) != len(want.Args) {
+		t.Fatalf("BuildConfig{}.withDefaults() = %+v, want %+v", got, want)
+	}
+
+	custom := BuildConfig{Command: "make"}.withDefaults()
+	if custom.Command != "make" {
+		t.Fatalf("withDefaults overwrote an explicit Command: %q", custom.Command)
+	}
+	if len(custom.Args) != 1 || custom.Args[0] != "build" {
+		t.Fatalf("withDefaults Args = %v, want default [\"build\"]", custom.Args)
+	}
+}
+
+func TestBootWithOptionsUpToDateSkipsBuildEvenWithoutSkipOption(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "byte-bin")
+	writeFile(t, binPath, "not a real binary")
+
+	// A BuildConfig naming a command that would fail loudly if actually
+	// run, so this test only passes if NeedsRebuild correctly finds the
+	// binary up to date and never reaches cargoBuild.
+	got, err := BootWithOptions(binPath, dir, BootOptions{Build: BuildConfig{Command: "definitely-not-a-real-command"}})
+	if err != nil {
+		t.Fatalf("BootWithOptions = %v, want nil (binary newer than any source in an empty dir)", err)
+	}
+	if got != binPath {
+		t.Fatalf("BootWithOptions = %q, want %q", got, binPath)
+	}
+}
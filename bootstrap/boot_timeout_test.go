@@ -0,0 +1,21 @@
+package bootstrap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCargoBuildTimeoutUnsetByDefault(t *testing.T) {
+	t.Setenv(cargoTimeoutEnv, "")
+	if _, ok := cargoBuildTimeout(); ok {
+		t.Fatal("cargoBuildTimeout ok = true with CARGO_TIMEOUT unset, want false")
+	}
+}
+
+func TestCargoBuildTimeoutParsesDuration(t *testing.T) {
+	t.Setenv(cargoTimeoutEnv, "90s")
+	d, ok := cargoBuildTimeout()
+	if !ok || d != 90*time.Second {
+		t.Fatalf("cargoBuildTimeout = (%v, %v), want (90s, true)", d, ok)
+	}
+}
@@ -0,0 +1,70 @@
+package bootstrap
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// byteIgnoreFileName is the per-directory file Glob honors to prune
+// whole directories from its walk, the ergonomic alternative to
+// passing explicit excludes on every call.
+const byteIgnoreFileName = ".byteignore"
+
+// loadByteIgnore reads dir's byteIgnoreFileName, if present, compiling
+// each non-blank, non-comment line as a glob pattern (the same `*`,
+// `**`, `?` syntax CompileGlob understands) matched against paths
+// relative to dir. It returns nil when there is no such file.
+func loadByteIgnore(dir string) ([]*regexp.Regexp, error) {
+	f, err := os.Open(filepath.Join(dir, byteIgnoreFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []*regexp.Regexp
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		re, err := CompileGlob(line, GlobCompileOptions{AnchorStart: true, AnchorEnd: true})
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, scanner.Err()
+}
+
+// byteIgnoreIsIgnored reports whether path (anywhere under walkRoot)
+// is excluded by a .byteignore file loaded into ignoreFiles, keyed by
+// the directory that held it. It checks path against every ancestor
+// directory's own rules, not just the nearest one, since an outer
+// .byteignore still applies to its whole subtree.
+func byteIgnoreIsIgnored(walkRoot, path string, ignoreFiles map[string][]*regexp.Regexp) bool {
+	for dir := filepath.Dir(path); ; {
+		if patterns, ok := ignoreFiles[dir]; ok {
+			rel := filepath.ToSlash(Relative(dir, path))
+			for _, re := range patterns {
+				if re.MatchString(rel) {
+					return true
+				}
+			}
+		}
+		if dir == walkRoot {
+			return false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,43 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// keep.byte sits directly in dir, so this also exercises "**/*.byte"
+// matching a file with zero intervening directories (see CompileGlob).
+func TestGlobHonorsByteIgnore(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "keep.byte"), "")
+	writeFile(t, filepath.Join(dir, "build", "generated.byte"), "")
+	if err := os.WriteFile(filepath.Join(dir, ".byteignore"), []byte("# ignore build output\nbuild/**\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := Glob(dir, "**/*.byte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0] != "keep.byte" {
+		t.Fatalf("Glob = %v, want just [keep.byte]", matches)
+	}
+}
+
+func TestGlobHonorsNestedByteIgnore(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "sub", "keep.byte"), "")
+	writeFile(t, filepath.Join(dir, "sub", "skip.byte"), "")
+	if err := os.WriteFile(filepath.Join(dir, "sub", ".byteignore"), []byte("skip.byte\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := Glob(dir, "**/*.byte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0] != "sub/keep.byte" {
+		t.Fatalf("Glob = %v, want just [sub/keep.byte]", matches)
+	}
+}
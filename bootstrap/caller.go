@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+)
+
+// Caller returns the file and line of the caller skip frames above the
+// function that called Caller itself (skip=0 means the immediate
+// caller).
+func Caller(skip int) (file string, line int) {
+	_, file, line, _ = runtime.Caller(1 + skip)
+	return file, line
+}
+
+// FileName returns the base name of the immediate caller's source file,
+// handy for short log prefixes.
+func FileName() string {
+	_, file, _, _ := runtime.Caller(1)
+	return filepath.Base(file)
+}
+
+// CallerStack returns up to depth formatted "file:line" frames above the
+// function that called CallerStack, starting skip frames up, for
+// annotating errors with more context than a single frame gives.
+func CallerStack(skip, depth int) []string {
+	frames := make([]string, 0, depth)
+	for i := 0; i < depth; i++ {
+		_, file, line, ok := runtime.Caller(1 + skip + i)
+		if !ok {
+			break
+		}
+		frames = append(frames, fmt.Sprintf("%s:%d", file, line))
+	}
+	return frames
+}
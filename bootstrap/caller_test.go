@@ -0,0 +1,19 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCallerStackIncludesTestFunction(t *testing.T) {
+	frames := CallerStack(0, 4)
+	found := false
+	for _, f := range frames {
+		if strings.Contains(f, "caller_test.go") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("CallerStack frames missing this test's file: %v", frames)
+	}
+}
@@ -0,0 +1,32 @@
+package bootstrap
+
+import "testing"
+
+// TestCheckResultWorksAgainstAnArbitraryCommand exercises the same
+// Run+CheckResult composition `byte check` uses, confirming CheckResult
+// works against output from any command, not just a script discovered
+// from a `.byte` file.
+func TestCheckResultWorksAgainstAnArbitraryCommand(t *testing.T) {
+	result, err := Run("echo", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test := &ScriptTest{Name: "echo", Expected: "hello\n"}
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("CheckResult = %v, want nil for matching output", err)
+	}
+}
+
+func TestCheckResultReportsAMismatchForAnArbitraryCommand(t *testing.T) {
+	result, err := Run("echo", "goodbye")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test := &ScriptTest{Name: "echo", Expected: "hello\n"}
+	err = CheckResult(test, result)
+	if _, ok := err.(*MismatchError); !ok {
+		t.Fatalf("CheckResult = %v (%T), want a *MismatchError", err, err)
+	}
+}
@@ -0,0 +1,96 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Comparator compares a test's expected output against its actual
+// output and reports whether they match, with a human-readable detail
+// describing any mismatch. Both arguments are the test's raw string
+// output by default; a comparator is free to parse them into a richer
+// value instead (see jsonSubsetComparator), which is why they're typed
+// any rather than string.
+type Comparator interface {
+	Compare(expected, actual any) (ok bool, detail string)
+}
+
+// ComparatorFunc adapts a plain function to the Comparator interface.
+type ComparatorFunc func(expected, actual any) (ok bool, detail string)
+
+func (f ComparatorFunc) Compare(expected, actual any) (bool, string) {
+	return f(expected, actual)
+}
+
+// comparators is the registry of named comparators selectable by a
+// test's `# comparator: <name>` directive. RegisterComparator adds to
+// it.
+var comparators = map[string]Comparator{
+	"exact":       ComparatorFunc(exactComparator),
+	"json-subset": ComparatorFunc(jsonSubsetComparator),
+}
+
+// RegisterComparator adds (or replaces) a named comparator, making it
+// selectable by a test's `# comparator: <name>` directive. Built-in
+// names are "exact" and "json-subset"; register your own for
+// domain-specific formats (CSV, table output, ...) this package has no
+// built-in support for.
+func RegisterComparator(name string, c Comparator) {
+	comparators[name] = c
+}
+
+// ComparatorByName looks up a registered comparator by name, reporting
+// false if none is registered under it.
+func ComparatorByName(name string) (Comparator, bool) {
+	c, ok := comparators[name]
+	return c, ok
+}
+
+// exactComparator is the "exact" built-in: a verbatim string match,
+// matching CheckResult's default (no directive) comparison.
+func exactComparator(expected, actual any) (bool, string) {
+	want, got := fmt.Sprint(expected), fmt.Sprint(actual)
+	if want == got {
+		return true, ""
+	}
+	return false, fmt.Sprintf("expected %q, got %q", want, got)
+}
+
+// jsonSubsetComparator is an example custom comparator: expected must
+// decode as a JSON object that's a subset of actual's decoded JSON
+// object — every key expected names must be present in actual with an
+// equal value, but actual may carry additional keys expected doesn't
+// mention. Useful for asserting on part of a larger, evolving JSON
+// output without pinning down the whole thing.
+func jsonSubsetComparator(expected, actual any) (bool, string) {
+	var want, got map[string]any
+	if err := json.Unmarshal([]byte(fmt.Sprint(expected)), &want); err != nil {
+		return false, fmt.Sprintf("expected is not a JSON object: %v", err)
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprint(actual)), &got); err != nil {
+		return false, fmt.Sprintf("actual is not a JSON object: %v", err)
+	}
+	for k, wantVal := range want {
+		gotVal, ok := got[k]
+		if !ok {
+			return false, fmt.Sprintf("missing key %q", k)
+		}
+		if fmt.Sprint(wantVal) != fmt.Sprint(gotVal) {
+			return false, fmt.Sprintf("key %q: expected %v, got %v", k, wantVal, gotVal)
+		}
+	}
+	return true, ""
+}
+
+// ComparatorMismatchError reports a mismatch detected by a named
+// Comparator, returned by CheckResult when the test declared a `#
+// comparator:` directive.
+type ComparatorMismatchError struct {
+	Test       string
+	Comparator string
+	Detail     string
+}
+
+func (e *ComparatorMismatchError) Error() string {
+	return fmt.Sprintf("%s: %s comparator mismatch: %s", e.Test, e.Comparator, e.Detail)
+}
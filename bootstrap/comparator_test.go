@@ -0,0 +1,97 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExactComparatorMatchesEqualStrings(t *testing.T) {
+	cmp, ok := ComparatorByName("exact")
+	if !ok {
+		t.Fatal("ComparatorByName(\"exact\") not found")
+	}
+	if ok, detail := cmp.Compare("hello", "hello"); !ok {
+		t.Fatalf("Compare(equal) = false, detail %q", detail)
+	}
+	if ok, _ := cmp.Compare("hello", "goodbye"); ok {
+		t.Fatal("Compare(unequal) = true, want false")
+	}
+}
+
+func TestJSONSubsetComparatorAcceptsASubset(t *testing.T) {
+	cmp, ok := ComparatorByName("json-subset")
+	if !ok {
+		t.Fatal("ComparatorByName(\"json-subset\") not found")
+	}
+	ok, detail := cmp.Compare(`{"status":"ok"}`, `{"status":"ok","latency_ms":12}`)
+	if !ok {
+		t.Fatalf("Compare(subset) = false, detail %q", detail)
+	}
+}
+
+func TestJSONSubsetComparatorRejectsAMissingKey(t *testing.T) {
+	cmp, _ := ComparatorByName("json-subset")
+	ok, detail := cmp.Compare(`{"status":"ok"}`, `{"latency_ms":12}`)
+	if ok {
+		t.Fatal("Compare(missing key) = true, want false")
+	}
+	if detail == "" {
+		t.Fatal("Compare(missing key) detail is empty")
+	}
+}
+
+func TestRegisterComparatorAddsACustomComparator(t *testing.T) {
+	RegisterComparator("always-ok-test", ComparatorFunc(func(expected, actual any) (bool, string) {
+		return true, ""
+	}))
+	defer delete(comparators, "always-ok-test")
+
+	test := &ScriptTest{Name: "t", Expected: "anything", Comparator: "always-ok-test"}
+	if err := CheckResult(test, RunResult{Stdout: "something else"}); err != nil {
+		t.Fatalf("CheckResult = %v, want nil for a custom comparator that always accepts", err)
+	}
+}
+
+func TestCheckResultDispatchesToNamedComparator(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: `{"status":"ok"}`, Comparator: "json-subset"}
+	result := RunResult{Stdout: `{"status":"ok","extra":true}`}
+
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("CheckResult = %v, want nil", err)
+	}
+}
+
+func TestCheckResultReportsComparatorMismatch(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: `{"status":"ok"}`, Comparator: "json-subset"}
+	result := RunResult{Stdout: `{"status":"failed"}`}
+
+	err := CheckResult(test, result)
+	mismatch, ok := err.(*ComparatorMismatchError)
+	if !ok {
+		t.Fatalf("CheckResult error = %v (%T), want *ComparatorMismatchError", err, err)
+	}
+	if mismatch.Comparator != "json-subset" {
+		t.Fatalf("Comparator = %q, want %q", mismatch.Comparator, "json-subset")
+	}
+}
+
+func TestCheckResultUnknownComparatorErrors(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "x", Comparator: "does-not-exist"}
+	if err := CheckResult(test, RunResult{Stdout: "x"}); err == nil {
+		t.Fatal("CheckResult = nil, want an error naming the unknown comparator")
+	}
+}
+
+func TestDiscoverTestsComparatorDirective(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "custom.byte"), "# comparator: json-subset\nprint(1)")
+	writeFile(t, filepath.Join(dir, "custom.out"), "")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || tests[0].Comparator != "json-subset" {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
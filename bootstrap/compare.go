@@ -0,0 +1,149 @@
+package bootstrap
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CompareMode selects how a script test's stdout is compared against
+// its expected output, set via a script's `# compare: <mode>`
+// directive.
+type CompareMode int
+
+const (
+	// CompareExact requires stdout to match the expected file verbatim.
+	// This is the default, matching historical behavior.
+	CompareExact CompareMode = iota
+	// CompareNumericTolerance parses each expected/actual line as a
+	// floating-point number and accepts them within Tolerance, falling
+	// back to an exact line comparison for lines that aren't numbers.
+	CompareNumericTolerance
+	// CompareRegex treats each expected line as a regular expression
+	// that the corresponding actual line must match.
+	CompareRegex
+)
+
+// String names mode the way directives and failure messages spell it.
+func (mode CompareMode) String() string {
+	switch mode {
+	case CompareNumericTolerance:
+		return "numeric-tolerance"
+	case CompareRegex:
+		return "regex"
+	default:
+		return "exact"
+	}
+}
+
+// parseCompareDirective parses a `# compare: <mode>` directive's value,
+// e.g. "regex" or "numeric-tolerance:1e-6".
+func parseCompareDirective(spec string) (CompareMode, float64, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "" || spec == "exact":
+		return CompareExact, 0, nil
+	case spec == "regex":
+		return CompareRegex, 0, nil
+	case spec == "numeric-tolerance":
+		return CompareNumericTolerance, 0, nil
+	case strings.HasPrefix(spec, "numeric-tolerance:"):
+		rest := strings.TrimPrefix(spec, "numeric-tolerance:")
+		tolerance, err := strconv.ParseFloat(rest, 64)
+		if err != nil {
+			return CompareExact, 0, fmt.Errorf("invalid numeric-tolerance value %q: %w", rest, err)
+		}
+		return CompareNumericTolerance, tolerance, nil
+	default:
+		return CompareExact, 0, fmt.Errorf("unknown compare mode %q", spec)
+	}
+}
+
+// compareDirective returns the CompareMode (and tolerance, if any)
+// declared by scriptPath's leading `# compare: <mode>` comment line,
+// defaulting to CompareExact when the directive is absent.
+func compareDirective(scriptPath string) (CompareMode, float64, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return CompareExact, 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if !strings.HasPrefix(directive, "compare:") {
+			continue
+		}
+		mode, tolerance, err := parseCompareDirective(strings.TrimPrefix(directive, "compare:"))
+		if err != nil {
+			return CompareExact, 0, fmt.Errorf("%s: %w", scriptPath, err)
+		}
+		return mode, tolerance, nil
+	}
+	return CompareExact, 0, scanner.Err()
+}
+
+// lineMatches reports whether actual satisfies expected under mode,
+// using tolerance for CompareNumericTolerance.
+func lineMatches(mode CompareMode, tolerance float64, expected, actual string) (bool, error) {
+	switch mode {
+	case CompareRegex:
+		re, err := regexp.Compile("^" + expected + "$")
+		if err != nil {
+			return false, fmt.Errorf("invalid regex expected line %q: %w", expected, err)
+		}
+		return re.MatchString(actual), nil
+	case CompareNumericTolerance:
+		want, errW := strconv.ParseFloat(expected, 64)
+		got, errG := strconv.ParseFloat(actual, 64)
+		if errW != nil || errG != nil {
+			return expected == actual, nil
+		}
+		return math.Abs(want-got) <= tolerance, nil
+	default:
+		return expected == actual, nil
+	}
+}
+
+// compareModeLines compares expected and actual line-by-line under
+// mode, returning whether every line matched.
+func compareModeLines(mode CompareMode, tolerance float64, expected, actual string) (bool, error) {
+	expectedLines := splitLines(expected)
+	actualLines := splitLines(actual)
+	if len(expectedLines) != len(actualLines) {
+		return false, nil
+	}
+	for i := range expectedLines {
+		ok, err := lineMatches(mode, tolerance, expectedLines[i], actualLines[i])
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// splitLines splits s into lines with trailing newlines and surrounding
+// whitespace removed, dropping the trailing empty line left by a final
+// "\n".
+func splitLines(s string) []string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return lines
+}
@@ -0,0 +1,88 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCompareDirective(t *testing.T) {
+	cases := []struct {
+		spec      string
+		mode      CompareMode
+		tolerance float64
+		wantErr   bool
+	}{
+		{"", CompareExact, 0, false},
+		{"exact", CompareExact, 0, false},
+		{"regex", CompareRegex, 0, false},
+		{"numeric-tolerance", CompareNumericTolerance, 0, false},
+		{"numeric-tolerance:1e-6", CompareNumericTolerance, 1e-6, false},
+		{"numeric-tolerance:nope", CompareExact, 0, true},
+		{"bogus", CompareExact, 0, true},
+	}
+	for _, c := range cases {
+		mode, tolerance, err := parseCompareDirective(c.spec)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseCompareDirective(%q) error = %v, wantErr %v", c.spec, err, c.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if mode != c.mode || tolerance != c.tolerance {
+			t.Errorf("parseCompareDirective(%q) = (%v, %v), want (%v, %v)", c.spec, mode, tolerance, c.mode, c.tolerance)
+		}
+	}
+}
+
+func writeScript(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCompareDirectiveFromScript(t *testing.T) {
+	dir := t.TempDir()
+	path := writeScript(t, dir, "a.byte", "# compare: numeric-tolerance:0.01\nprint(1)\n")
+
+	mode, tolerance, err := compareDirective(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode != CompareNumericTolerance || tolerance != 0.01 {
+		t.Fatalf("compareDirective = (%v, %v), want (numeric-tolerance, 0.01)", mode, tolerance)
+	}
+}
+
+func TestCheckResultNumericTolerancePasses(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "1.0000\n", CompareMode: CompareNumericTolerance, CompareTolerance: 1e-3}
+	if err := CheckResult(test, RunResult{Stdout: "1.0004\n"}); err != nil {
+		t.Fatalf("expected tolerance to absorb the difference, got %v", err)
+	}
+}
+
+func TestCheckResultNumericToleranceFails(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "1.0000\n", CompareMode: CompareNumericTolerance, CompareTolerance: 1e-6}
+	err := CheckResult(test, RunResult{Stdout: "1.0004\n"})
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("expected *MismatchError, got %v (%T)", err, err)
+	}
+	if mismatch.Mode != CompareNumericTolerance || mismatch.Tolerance != 1e-6 {
+		t.Fatalf("mismatch did not report the tolerance used: %+v", mismatch)
+	}
+}
+
+func TestCheckResultRegexMode(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "hello .*\n", CompareMode: CompareRegex}
+	if err := CheckResult(test, RunResult{Stdout: "hello world\n"}); err != nil {
+		t.Fatalf("expected regex line to match, got %v", err)
+	}
+	if err := CheckResult(test, RunResult{Stdout: "goodbye world\n"}); err == nil {
+		t.Fatal("expected regex mismatch to fail")
+	}
+}
@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// configFileName is the per-directory config file that customizes every
+// ScriptTest discovered in that directory.
+const configFileName = "byte-test.json"
+
+// dirConfig is the on-disk shape of a configFileName file.
+type dirConfig struct {
+	StderrPolicy string `json:"stderr_policy"`
+
+	// JSONExts lists additional extensions (beyond jsonExpectedExt) that
+	// count as a JSON-array expected-output sidecar for scripts in this
+	// directory, e.g. ["json"] to also recognize "<name>.json".
+	JSONExts []string `json:"json_exts"`
+}
+
+// loadDirConfig reads dir's configFileName, if present, returning the
+// zero dirConfig when there is none.
+func loadDirConfig(dir string) (dirConfig, error) {
+	data, err := os.ReadFile(filepath.Join(dir, configFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return dirConfig{}, nil
+		}
+		return dirConfig{}, err
+	}
+	var cfg dirConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return dirConfig{}, err
+	}
+	return cfg, nil
+}
+
+// apply overrides test's settings with whatever cfg specifies.
+func (cfg dirConfig) apply(test *ScriptTest) {
+	switch cfg.StderrPolicy {
+	case "ignore":
+		test.StderrPolicy = StderrIgnore
+	case "match":
+		test.StderrPolicy = StderrMatch
+	case "strict", "":
+		// Leave the default (StderrStrict) untouched.
+	}
+}
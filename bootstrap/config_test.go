@@ -0,0 +1,24 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsHonorsDirConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "byte-test.json"), `{"stderr_policy": "ignore"}`)
+	writeFile(t, filepath.Join(dir, "warns.byte"), "")
+	writeFile(t, filepath.Join(dir, "warns.out"), "")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("got %d tests, want 1", len(tests))
+	}
+	if tests[0].StderrPolicy != StderrIgnore {
+		t.Fatalf("StderrPolicy = %v, want StderrIgnore", tests[0].StderrPolicy)
+	}
+}
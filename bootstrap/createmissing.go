@@ -0,0 +1,124 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CreateMissing walks dir for `.byte` scripts that have no
+// expected-output source at all — no `.out`/`.out.json` (or
+// dirConfig-listed JSON-ext) sidecar, `# expect-cmd` directive, or
+// inline "# --- expected ---" block — runs each one, and writes its
+// captured stdout as a new `<name>.out` file. A script is only
+// golden-ed from a clean run: one that exits 0 with no execution error.
+// A `# skip`-ped script is left untouched, same as it would be by a
+// normal test run. It returns the name of every script it created an
+// expectation for, in sorted order, and prints a progress line to out
+// for each candidate (CREATED, or why it was skipped).
+//
+// Unlike a hypothetical "-update" mode, CreateMissing never touches a
+// script that already has an expectation of any kind — it exists only
+// to seed the very first `.out` for a brand-new test, not to refresh a
+// stale one.
+func CreateMissing(ctx context.Context, dir, binPath string, out io.Writer) ([]string, error) {
+	var candidates []string
+	configs := map[string]dirConfig{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != scriptExt {
+			return nil
+		}
+
+		scriptDir := filepath.Dir(path)
+		cfg, ok := configs[scriptDir]
+		if !ok {
+			cfg, err = loadDirConfig(scriptDir)
+			if err != nil {
+				return err
+			}
+			configs[scriptDir] = cfg
+		}
+
+		skipped, _, err := skipDirective(path)
+		if err != nil {
+			return err
+		}
+		if skipped {
+			return nil
+		}
+
+		covered, err := hasExpectedSource(path, cfg)
+		if err != nil {
+			return err
+		}
+		if !covered {
+			candidates = append(candidates, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(candidates)
+
+	var created []string
+	for _, path := range candidates {
+		name := strings.TrimSuffix(filepath.Base(path), scriptExt)
+		scriptDir := filepath.Dir(path)
+
+		result, runErr := RunContext(ctx, binPath, []string{path}, RunOptions{Dir: scriptDir})
+		if runErr != nil {
+			fmt.Fprintf(out, "skip %s: running script: %v\n", name, runErr)
+			continue
+		}
+		if result.ExitCode != 0 {
+			fmt.Fprintf(out, "skip %s: exited %d, not creating an expectation from a failing run\n", name, result.ExitCode)
+			continue
+		}
+
+		expectedPath := strings.TrimSuffix(path, scriptExt) + expectedExt
+		if err := writeFileAtomic(expectedPath, []byte(result.Stdout)); err != nil {
+			return created, fmt.Errorf("%s: writing %s: %w", name, expectedPath, err)
+		}
+		fmt.Fprintf(out, "CREATED: %s\n", name)
+		created = append(created, name)
+	}
+	return created, nil
+}
+
+// hasExpectedSource reports whether path already has some form of
+// expected-output source, matching the same present-file detection
+// discoverTests uses, so CreateMissing knows to leave it alone.
+func hasExpectedSource(path string, cfg dirConfig) (bool, error) {
+	base := strings.TrimSuffix(path, scriptExt)
+	if fileExists(base+expectedExt) || fileExists(base+expectedExt+gzExt) {
+		return true, nil
+	}
+	for _, ext := range append([]string{jsonExpectedExt}, cfg.JSONExts...) {
+		if fileExists(base + normalizeExt(ext)) {
+			return true, nil
+		}
+	}
+
+	cmd, err := expectCmdDirective(path)
+	if err != nil {
+		return false, err
+	}
+	if cmd != "" {
+		return true, nil
+	}
+
+	_, ok, err := inlineExpectedBlock(path)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
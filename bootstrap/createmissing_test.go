@@ -0,0 +1,85 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateMissingWritesOutFileFromCleanRun(t *testing.T) {
+	dir := t.TempDir()
+	scriptPath := filepath.Join(dir, "new.byte")
+	writeFile(t, scriptPath, "print(1)")
+
+	var buf bytes.Buffer
+	created, err := CreateMissing(context.Background(), dir, "echo", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || created[0] != "new" {
+		t.Fatalf("created = %v, want [new]", created)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "new.out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != scriptPath+"\n" {
+		t.Fatalf("new.out = %q, want echoed script path", got)
+	}
+}
+
+func TestCreateMissingSkipsScriptsThatAlreadyHaveAnExpectation(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "covered.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "covered.out"), "1\n")
+
+	var buf bytes.Buffer
+	created, err := CreateMissing(context.Background(), dir, "echo", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("created = %v, want none for an already-covered script", created)
+	}
+	if got, err := os.ReadFile(filepath.Join(dir, "covered.out")); err != nil || string(got) != "1\n" {
+		t.Fatalf("covered.out was modified: %q, %v", got, err)
+	}
+}
+
+func TestCreateMissingRefusesANonZeroExit(t *testing.T) {
+	dir := t.TempDir()
+	scriptPath := filepath.Join(dir, "broken.byte")
+	writeFile(t, scriptPath, "print(1)")
+
+	var buf bytes.Buffer
+	created, err := CreateMissing(context.Background(), dir, "false", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("created = %v, want none for a failing run", created)
+	}
+	if fileExists(filepath.Join(dir, "broken.out")) {
+		t.Fatal("broken.out should not have been created from a non-zero exit")
+	}
+}
+
+func TestCreateMissingLeavesSkippedScriptsAlone(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "skipped.byte"), "# skip: not ready\nprint(1)")
+
+	var buf bytes.Buffer
+	created, err := CreateMissing(context.Background(), dir, "echo", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("created = %v, want none for a skipped script", created)
+	}
+	if fileExists(filepath.Join(dir, "skipped.out")) {
+		t.Fatal("skipped.out should not have been created")
+	}
+}
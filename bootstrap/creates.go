@@ -0,0 +1,125 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ax-lab/byte/diff"
+)
+
+// createsExt is the extension for a script's "files it must create"
+// sidecar, see loadCreatesSpec.
+const createsExt = ".creates.json"
+
+// loadCreatesSpec reads scriptPath's `<name>.creates.json` sidecar, if
+// any, mapping each file the script is expected to create (relative to
+// the script's directory) to its expected contents. A value beginning
+// with "@" names another file, relative to the same directory, whose
+// contents are the expectation instead (an `.out`-style indirection,
+// for expectations too large or binary-ish to inline in the JSON). A
+// missing sidecar returns (nil, nil).
+func loadCreatesSpec(scriptPath string) (map[string]string, error) {
+	path := strings.TrimSuffix(scriptPath, scriptExt) + createsExt
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var spec map[string]string
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// safeJoin joins dir and rel, rejecting a rel that's absolute or that
+// escapes dir via "..", so a `.creates.json` sidecar can't be used to
+// read or delete files outside the test's own directory.
+func safeJoin(dir, rel string) (string, error) {
+	if filepath.IsAbs(rel) {
+		return "", fmt.Errorf("path %q must be relative to the test directory", rel)
+	}
+	joined := filepath.Join(dir, rel)
+	cleanDir := filepath.Clean(dir)
+	if joined != cleanDir && !strings.HasPrefix(joined, cleanDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q escapes the test directory", rel)
+	}
+	return joined, nil
+}
+
+// resolveCreatesExpectation returns want's literal content, or, if want
+// starts with "@", the contents of the file it names (relative to dir).
+func resolveCreatesExpectation(dir, want string) (string, error) {
+	ref, ok := strings.CutPrefix(want, "@")
+	if !ok {
+		return want, nil
+	}
+	path, err := safeJoin(dir, ref)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// cleanupCreatedFiles removes every file test.Creates names, if
+// present, before the test runs, so a file left over from a previous
+// run can't make this run's assertion pass without the script actually
+// having created it.
+func cleanupCreatedFiles(test *ScriptTest) error {
+	for rel := range test.Creates {
+		path, err := safeJoin(test.Dir, rel)
+		if err != nil {
+			return fmt.Errorf("%s: %w", test.Name, err)
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("%s: removing %s before run: %w", test.Name, rel, err)
+		}
+	}
+	return nil
+}
+
+// checkCreatedFiles verifies that every file test.Creates names was
+// produced by the test's run with the expected content, returning a
+// descriptive error for the first missing or mismatched file.
+func checkCreatedFiles(test *ScriptTest) error {
+	for rel, want := range test.Creates {
+		path, err := safeJoin(test.Dir, rel)
+		if err != nil {
+			return fmt.Errorf("%s: %w", test.Name, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("%s: expected file %s was not created", test.Name, rel)
+			}
+			return fmt.Errorf("%s: reading created file %s: %w", test.Name, rel, err)
+		}
+
+		expected, err := resolveCreatesExpectation(test.Dir, want)
+		if err != nil {
+			return fmt.Errorf("%s: %w", test.Name, err)
+		}
+
+		actual := string(data)
+		if actual != expected {
+			return &MismatchError{
+				Test:     fmt.Sprintf("%s (created file %s)", test.Name, rel),
+				Expected: expected,
+				Actual:   actual,
+				Blocks:   diff.Compare(strings.SplitAfter(expected, "\n"), strings.SplitAfter(actual, "\n")),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsLoadsCreatesSpec(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "writer.byte"), "")
+	writeFile(t, filepath.Join(dir, "writer.out"), "")
+	writeFile(t, filepath.Join(dir, "writer.creates.json"), `{"out.txt": "hello\n"}`)
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || tests[0].Creates["out.txt"] != "hello\n" {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
+
+func TestCheckCreatedFilesMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	test := &ScriptTest{Name: "t", Dir: dir, Creates: map[string]string{"out.txt": "hello\n"}}
+
+	if err := checkCreatedFiles(test); err == nil {
+		t.Fatal("expected an error for a file the script never created")
+	}
+}
+
+func TestCheckCreatedFilesContentMismatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "out.txt"), []byte("goodbye\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	test := &ScriptTest{Name: "t", Dir: dir, Creates: map[string]string{"out.txt": "hello\n"}}
+
+	if err := checkCreatedFiles(test); err == nil {
+		t.Fatal("expected an error for mismatched content")
+	}
+}
+
+func TestCheckCreatedFilesPasses(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "out.txt"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	test := &ScriptTest{Name: "t", Dir: dir, Creates: map[string]string{"out.txt": "hello\n"}}
+
+	if err := checkCreatedFiles(test); err != nil {
+		t.Fatalf("CheckCreatedFiles = %v, want nil", err)
+	}
+}
+
+func TestCheckCreatedFilesRejectsPathEscape(t *testing.T) {
+	dir := t.TempDir()
+	test := &ScriptTest{Name: "t", Dir: dir, Creates: map[string]string{"../escape.txt": "x"}}
+
+	if err := checkCreatedFiles(test); err == nil {
+		t.Fatal("expected an error for a path escaping the test directory")
+	}
+}
+
+func TestCheckCreatedFilesResolvesFileReference(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "out.txt"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "out.expected"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	test := &ScriptTest{Name: "t", Dir: dir, Creates: map[string]string{"out.txt": "@out.expected"}}
+
+	if err := checkCreatedFiles(test); err != nil {
+		t.Fatalf("CheckCreatedFiles = %v, want nil", err)
+	}
+}
+
+func TestCleanupCreatedFilesRemovesStaleOutput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(path, []byte("stale\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	test := &ScriptTest{Name: "t", Dir: dir, Creates: map[string]string{"out.txt": "hello\n"}}
+
+	if err := cleanupCreatedFiles(test); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("expected out.txt to be removed")
+	}
+}
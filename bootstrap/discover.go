@@ -0,0 +1,573 @@
+package bootstrap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// scriptExt is the extension for byte script test sources.
+const scriptExt = ".byte"
+
+// expectedExt is the extension for a script's expected stdout file.
+const expectedExt = ".out"
+
+// jsonExpectedExt is the extension for a script's JSON-array expected
+// stdout file, compared line-by-line via JSONExpected.
+const jsonExpectedExt = ".out.json"
+
+// fileExists reports whether os.Stat succeeds for path; it does not
+// check that path is a regular file or that it can be read.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// normalizeExt ensures ext starts with a leading dot, so dirConfig's
+// JSONExts can be written either as "json" or ".json".
+func normalizeExt(ext string) string {
+	if strings.HasPrefix(ext, ".") {
+		return ext
+	}
+	return "." + ext
+}
+
+// discoverTests walks dir for `<name>.byte` scripts and returns, in
+// sorted order, one ScriptTest for each script that has an expectation:
+// a sibling `<name>.out` (or gzipped) file, a `<name>.out.json` file or
+// one with a JSON extension configured for its directory, an
+// `# expect-cmd` directive, or an inline expected-output block. Skipped
+// scripts are returned whether or not they have one; any other script
+// without an expectation is left out.
+func discoverTests(dir string) ([]*ScriptTest, error) {
+	configs := map[string]dirConfig{}
+	dotEnvs := map[string][]string{}
+	var tests []*ScriptTest
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != scriptExt {
+			return nil
+		}
+		name := strings.TrimSuffix(filepath.Base(path), scriptExt)
+		test := &ScriptTest{
+			Name:   name,
+			Dir:    filepath.Dir(path),
+			Source: path,
+		}
+
+		skipped, reason, err := skipDirective(path)
+		if err != nil {
+			return err
+		}
+		test.Skipped, test.SkipReason = skipped, reason
+
+		only, err := onlyDirective(path)
+		if err != nil {
+			return err
+		}
+		test.Only = only
+
+		after, err := afterDirective(path)
+		if err != nil {
+			return err
+		}
+		test.After = after
+
+		mode, tolerance, err := compareDirective(path)
+		if err != nil {
+			return err
+		}
+		test.CompareMode, test.CompareTolerance = mode, tolerance
+
+		xfail, xfailReason, err := xfailDirective(path)
+		if err != nil {
+			return err
+		}
+		test.XFail, test.XFailReason = xfail, xfailReason
+
+		stripANSI, err := stripAnsiDirective(path)
+		if err != nil {
+			return err
+		}
+		test.StripANSI = stripANSI
+
+		stripPrefix, err := stripPrefixDirective(path)
+		if err != nil {
+			return err
+		}
+		test.StripPrefix = stripPrefix
+
+		redactors, err := redactDirective(path)
+		if err != nil {
+			return err
+		}
+		test.Redactors = redactors
+
+		comparator, err := comparatorDirective(path)
+		if err != nil {
+			return err
+		}
+		test.Comparator = comparator
+
+		workDir, err := workDirDirective(path)
+		if err != nil {
+			return err
+		}
+		test.WorkDir = workDir
+
+		creates, err := loadCreatesSpec(path)
+		if err != nil {
+			return err
+		}
+		test.Creates = creates
+
+		expectCmd, err := expectCmdDirective(path)
+		if err != nil {
+			return err
+		}
+		test.ExpectCmd = expectCmd
+
+		dotEnv, ok := dotEnvs[test.Dir]
+		if !ok {
+			dotEnv, err = loadDotEnv(test.Dir)
+			if err != nil {
+				return err
+			}
+			dotEnvs[test.Dir] = dotEnv
+		}
+		ownEnv, err := envDirective(path)
+		if err != nil {
+			return err
+		}
+		test.Env = append(append([]string{}, dotEnv...), ownEnv...)
+
+		cfg, ok := configs[test.Dir]
+		if !ok {
+			cfg, err = loadDirConfig(test.Dir)
+			if err != nil {
+				return err
+			}
+			configs[test.Dir] = cfg
+		}
+
+		if !test.Skipped {
+			base := strings.TrimSuffix(path, scriptExt)
+
+			jsonCandidates := append([]string{jsonExpectedExt}, cfg.JSONExts...)
+			var jsonPath string
+			var present []string
+			if fileExists(base+expectedExt) || fileExists(base+expectedExt+gzExt) {
+				present = append(present, expectedExt)
+			}
+			for _, ext := range jsonCandidates {
+				ext = normalizeExt(ext)
+				if fileExists(base + ext) {
+					present = append(present, ext)
+					if jsonPath == "" {
+						jsonPath = base + ext
+					}
+				}
+			}
+
+			switch {
+			case len(present) > 1:
+				return fmt.Errorf("%s: has more than one expected-output file: %s", name, strings.Join(present, ", "))
+			case len(present) == 1 && test.ExpectCmd != "":
+				return fmt.Errorf("%s: has both an expected-output file (%s) and an `# expect-cmd` directive", name, present[0])
+			case len(present) == 0 && test.ExpectCmd != "":
+				expected, err := runExpectCmd(test.ExpectCmd, test.Dir)
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				test.Expected = expected
+			case len(present) == 0:
+				inline, ok, err := inlineExpectedBlock(path)
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				if !ok {
+					return nil
+				}
+				test.Expected = inline
+			case present[0] == expectedExt:
+				expected, err := ReadTextMaybeGz(base + expectedExt)
+				if err != nil {
+					return err
+				}
+				test.Expected = expected
+			default:
+				jf, err := os.Open(jsonPath)
+				if err != nil {
+					return err
+				}
+				data, err := readAllWithLimit(jf, MaxReadBytes, jsonPath)
+				jf.Close()
+				if err != nil {
+					return err
+				}
+				if err := json.Unmarshal(data, &test.JSONExpected); err != nil {
+					return fmt.Errorf("%s: parsing %s: %w", name, present[0], err)
+				}
+			}
+		}
+
+		cfg.apply(test)
+
+		tests = append(tests, test)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tests, func(i, j int) bool { return tests[i].Name < tests[j].Name })
+	return tests, nil
+}
+
+// skipDirective reports whether scriptPath (or its `<name>.skip`
+// sidecar) declares the test skipped, and why. Recognized forms in the
+// script's leading comment lines are `# skip: reason`, `# skip`, and
+// `# skip-if-<goos>`, which only applies on that GOOS.
+func skipDirective(scriptPath string) (skipped bool, reason string, err error) {
+	if data, err := os.ReadFile(strings.TrimSuffix(scriptPath, scriptExt) + ".skip"); err == nil {
+		return true, strings.TrimSpace(string(data)), nil
+	} else if !os.IsNotExist(err) {
+		return false, "", err
+	}
+
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return false, "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+
+		switch {
+		case directive == "skip":
+			return true, "", nil
+		case strings.HasPrefix(directive, "skip:"):
+			return true, strings.TrimSpace(strings.TrimPrefix(directive, "skip:")), nil
+		case strings.HasPrefix(directive, "skip-if-"):
+			goos := strings.TrimPrefix(directive, "skip-if-")
+			if goos == runtime.GOOS {
+				return true, "skip-if-" + goos, nil
+			}
+		}
+	}
+	return false, "", scanner.Err()
+}
+
+// envDirective returns the KEY=VALUE pairs declared by scriptPath's
+// leading `# env: KEY=VALUE` comment lines, one pair per line. These
+// override the directory's .env file for that key, so a test can pin
+// down a variable its own .env relies on being variable.
+func envDirective(scriptPath string) ([]string, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var env []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if !strings.HasPrefix(directive, "env:") {
+			continue
+		}
+		pair := strings.TrimSpace(strings.TrimPrefix(directive, "env:"))
+		if !strings.Contains(pair, "=") {
+			return nil, fmt.Errorf("%s: invalid `# env:` directive %q, expected KEY=VALUE", scriptPath, pair)
+		}
+		env = append(env, pair)
+	}
+	return env, scanner.Err()
+}
+
+// afterDirective returns the prerequisite test names declared by
+// scriptPath's leading `# after: name1, name2` comment line, if any.
+func afterDirective(scriptPath string) ([]string, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if !strings.HasPrefix(directive, "after:") {
+			continue
+		}
+
+		var names []string
+		for _, name := range strings.Split(strings.TrimPrefix(directive, "after:"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names, nil
+	}
+	return nil, scanner.Err()
+}
+
+// xfailDirective reports whether scriptPath's leading comment lines
+// carry an `# xfail: reason` (or bare `# xfail`) marker, documenting a
+// known-broken test.
+func xfailDirective(scriptPath string) (xfail bool, reason string, err error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return false, "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+
+		switch {
+		case directive == "xfail":
+			return true, "", nil
+		case strings.HasPrefix(directive, "xfail:"):
+			return true, strings.TrimSpace(strings.TrimPrefix(directive, "xfail:")), nil
+		}
+	}
+	return false, "", scanner.Err()
+}
+
+// stripAnsiDirective reports whether scriptPath's leading comment
+// lines carry a `# strip-ansi` marker, which strips terminal escape
+// sequences from stdout before comparing it against the expected
+// output.
+func stripAnsiDirective(scriptPath string) (bool, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if strings.TrimSpace(strings.TrimPrefix(line, "#")) == "strip-ansi" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// expectCmdDirective returns the shell command declared by scriptPath's
+// leading `# expect-cmd: some command` comment line, or "" if absent.
+// discoverTests runs this command's stdout as the test's expected
+// output instead of reading a `.out` file.
+func expectCmdDirective(scriptPath string) (string, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if strings.HasPrefix(directive, "expect-cmd:") {
+			return strings.TrimSpace(strings.TrimPrefix(directive, "expect-cmd:")), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// stripPrefixDirective returns the regex declared by scriptPath's
+// leading `# strip-prefix: <regex>` comment line, compiled, or nil if
+// absent. discoverTests uses it to strip a matching leading prefix
+// (e.g. a log timestamp) from each line of stdout and Expected before
+// they're compared.
+func stripPrefixDirective(scriptPath string) (*regexp.Regexp, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if strings.HasPrefix(directive, "strip-prefix:") {
+			pattern := strings.TrimSpace(strings.TrimPrefix(directive, "strip-prefix:"))
+			re, err := regexp.Compile("^(?:" + pattern + ")")
+			if err != nil {
+				return nil, fmt.Errorf("%s: strip-prefix: %w", scriptPath, err)
+			}
+			return re, nil
+		}
+	}
+	return nil, scanner.Err()
+}
+
+// comparatorDirective returns the name declared by scriptPath's leading
+// `# comparator: <name>` comment line, or "" if absent. discoverTests
+// doesn't validate the name against the registry itself, since a
+// comparator registered by the embedder via RegisterComparator may not
+// exist yet at discovery time; CheckResult reports an unknown name.
+func comparatorDirective(scriptPath string) (string, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if strings.HasPrefix(directive, "comparator:") {
+			return strings.TrimSpace(strings.TrimPrefix(directive, "comparator:")), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// redactDirective returns the Redactors named by scriptPath's leading
+// `# redact: <name>` (or comma-separated `# redact: <name>,<name>`)
+// comment line, looked up via RedactorByName, or nil if absent.
+func redactDirective(scriptPath string) ([]Redactor, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if !strings.HasPrefix(directive, "redact:") {
+			continue
+		}
+		var redactors []Redactor
+		for _, name := range strings.Split(strings.TrimPrefix(directive, "redact:"), ",") {
+			name = strings.TrimSpace(name)
+			r, ok := RedactorByName(name)
+			if !ok {
+				return nil, fmt.Errorf("%s: redact: unknown redactor %q", scriptPath, name)
+			}
+			redactors = append(redactors, r)
+		}
+		return redactors, nil
+	}
+	return nil, scanner.Err()
+}
+
+// inlineExpectedMarker starts an inline expected-output block at the
+// end of a `.byte` script, for small tests that would rather not
+// maintain a separate `.out` file. Every line after the marker must be
+// a `#`-prefixed comment; the `#` (and one following space, if any) is
+// stripped from each to form the expected output, one line per line,
+// through the end of the file. A completely empty expected line is
+// written as a bare `#`.
+const inlineExpectedMarker = "# --- expected ---"
+
+// inlineExpectedBlock looks for scriptPath's inlineExpectedMarker and
+// returns the expected output it delimits. ok is false if the script
+// has no such marker, so callers can fall back to treating it as not a
+// test at all (the historical behavior for a `.byte` file with no
+// expectation).
+func inlineExpectedBlock(scriptPath string) (expected string, ok bool, err error) {
+	data, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return "", false, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == inlineExpectedMarker {
+			start = i + 1
+			break
+		}
+	}
+	if start == -1 {
+		return "", false, nil
+	}
+
+	var out []string
+	for _, line := range lines[start:] {
+		trimmed := strings.TrimRight(line, "\r")
+		if !strings.HasPrefix(trimmed, "#") {
+			return "", false, fmt.Errorf("line %q after %q must be a `#`-prefixed comment", trimmed, inlineExpectedMarker)
+		}
+		content := strings.TrimPrefix(strings.TrimPrefix(trimmed, "#"), " ")
+		out = append(out, content)
+	}
+	return strings.Join(out, "\n") + "\n", true, nil
+}
+
+// onlyDirective reports whether scriptPath's leading comment lines
+// carry a `# only` marker, focusing the run on just that test (and any
+// others marked the same way).
+func onlyDirective(scriptPath string) (bool, error) {
+	f, err := os.Open(scriptPath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if strings.TrimSpace(strings.TrimPrefix(line, "#")) == "only" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,24 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsPairsScriptsWithExpectedOutput(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "hello.byte"), "print(\"hi\")")
+	writeFile(t, filepath.Join(dir, "hello.out"), "hi\n")
+	writeFile(t, filepath.Join(dir, "no_expectation.byte"), "print(\"skip me\")")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("got %d tests, want 1: %+v", len(tests), tests)
+	}
+	if tests[0].Name != "hello" || tests[0].Expected != "hi\n" {
+		t.Fatalf("unexpected test: %+v", tests[0])
+	}
+}
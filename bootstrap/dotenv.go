@@ -0,0 +1,65 @@
+package bootstrap
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dotEnvFileName is the per-directory file holding environment
+// variables for scripts run in that directory.
+const dotEnvFileName = ".env"
+
+// parseDotEnv parses a minimal dotenv format: blank lines and lines
+// starting with `#` are ignored, and every other line must be
+// `KEY=VALUE`. VALUE may be wrapped in single or double quotes, which
+// are stripped; there is no further escaping or shell expansion. It
+// returns entries in the repo's usual "KEY=VALUE" form, ready to pass
+// as RunOptions.Env.
+func parseDotEnv(data []byte) ([]string, error) {
+	var env []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE, got %q", dotEnvFileName, lineNum, line)
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(strings.TrimSpace(value))
+		env = append(env, key+"="+value)
+	}
+	return env, scanner.Err()
+}
+
+// unquote strips a single matching pair of leading/trailing single or
+// double quotes from s, if present.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	first, last := s[0], s[len(s)-1]
+	if (first == '"' || first == '\'') && first == last {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// loadDotEnv reads dir's dotEnvFileName, returning nil when there is
+// none.
+func loadDotEnv(dir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, dotEnvFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseDotEnv(data)
+}
@@ -0,0 +1,64 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	data := []byte(`
+# a comment
+FOO=bar
+BAZ="quoted value"
+QUX='single quoted'
+
+# another comment
+EMPTY=
+`)
+	env, err := parseDotEnv(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"FOO=bar", "BAZ=quoted value", "QUX=single quoted", "EMPTY="}
+	if !reflect.DeepEqual(env, want) {
+		t.Fatalf("parseDotEnv = %v, want %v", env, want)
+	}
+}
+
+func TestParseDotEnvRejectsMalformedLine(t *testing.T) {
+	if _, err := parseDotEnv([]byte("not-a-key-value\n")); err == nil {
+		t.Fatal("expected an error for a line without '='")
+	}
+}
+
+func TestLoadDotEnvMissingIsNil(t *testing.T) {
+	env, err := loadDotEnv(t.TempDir())
+	if err != nil || env != nil {
+		t.Fatalf("loadDotEnv on a directory with no .env = (%v, %v), want (nil, nil)", env, err)
+	}
+}
+
+func TestDiscoverTestsMergesDotEnvAndDirective(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("FOO=from-dotenv\nSHARED=from-dotenv\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeScript(t, dir, "a.byte", "# env: SHARED=from-directive\nprint(1)\n")
+	if err := os.WriteFile(filepath.Join(dir, "a.out"), []byte("1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("got %d tests, want 1", len(tests))
+	}
+	want := []string{"FOO=from-dotenv", "SHARED=from-dotenv", "SHARED=from-directive"}
+	if !reflect.DeepEqual(tests[0].Env, want) {
+		t.Fatalf("Env = %v, want %v", tests[0].Env, want)
+	}
+}
@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunWithEnvAllowlistExcludesNonAllowlistedVars(t *testing.T) {
+	t.Setenv("BYTE_TEST_SECRET", "leaked")
+	t.Setenv("BYTE_TEST_KEPT", "kept")
+
+	result, err := RunWith("sh", []string{"-c", "echo \"$BYTE_TEST_SECRET|$BYTE_TEST_KEPT\""}, RunOptions{
+		EnvAllowlist: []string{"BYTE_TEST_KEPT"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "|kept" {
+		t.Fatalf("stdout = %q, want %q", got, "|kept")
+	}
+}
+
+func TestRunWithEnvAllowlistEmptySliceStripsEverything(t *testing.T) {
+	t.Setenv("BYTE_TEST_SECRET", "leaked")
+
+	result, err := RunWith("sh", []string{"-c", "echo \"[$BYTE_TEST_SECRET]\""}, RunOptions{
+		EnvAllowlist: []string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "[]" {
+		t.Fatalf("stdout = %q, want %q", got, "[]")
+	}
+}
+
+func TestRunWithEnvAllowlistStillAppliesEnvOnTop(t *testing.T) {
+	result, err := RunWith("sh", []string{"-c", "echo \"$BYTE_TEST_ADDED\""}, RunOptions{
+		EnvAllowlist: []string{},
+		Env:          []string{"BYTE_TEST_ADDED=added"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "added" {
+		t.Fatalf("stdout = %q, want %q", got, "added")
+	}
+}
+
+func TestRunWithoutEnvAllowlistInheritsFullEnvironment(t *testing.T) {
+	t.Setenv("BYTE_TEST_AMBIENT", "ambient")
+	result, err := RunWith("sh", []string{"-c", "echo \"$BYTE_TEST_AMBIENT\""}, RunOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "ambient" {
+		t.Fatalf("stdout = %q, want %q", got, "ambient")
+	}
+}
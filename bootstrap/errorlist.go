@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorList accumulates errors from a batch of fallible steps (e.g. a
+// validation pass checking many independent things) so they can all be
+// reported together instead of exiting on the first one found.
+type ErrorList struct {
+	errs []error
+}
+
+// Add records err, prefixed with msg, if err is non-nil. A nil err is a
+// no-op, so callers can unconditionally call Add after every step
+// without their own if-check.
+func (l *ErrorList) Add(err error, msg string) {
+	if err == nil {
+		return
+	}
+	l.errs = append(l.errs, fmt.Errorf("%s: %w", msg, err))
+}
+
+// ErrOrNil joins every error recorded so far via errors.Join, or returns
+// nil if nothing was recorded. It's the batch counterpart to a plain
+// "return err if non-nil" check; ErrOrNil never logs or exits itself —
+// callers decide when and how to surface the aggregated error.
+func (l *ErrorList) ErrOrNil() error {
+	return errors.Join(l.errs...)
+}
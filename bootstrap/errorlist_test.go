@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorListErrOrNilEmptyIsNil(t *testing.T) {
+	var l ErrorList
+	if err := l.ErrOrNil(); err != nil {
+		t.Fatalf("ErrOrNil = %v, want nil", err)
+	}
+}
+
+func TestErrorListAddIgnoresNil(t *testing.T) {
+	var l ErrorList
+	l.Add(nil, "step 1")
+	if err := l.ErrOrNil(); err != nil {
+		t.Fatalf("ErrOrNil = %v, want nil after adding only nil errors", err)
+	}
+}
+
+func TestErrorListAggregatesAndFormats(t *testing.T) {
+	var l ErrorList
+	l.Add(errors.New("boom"), "step 1")
+	l.Add(errors.New("bang"), "step 2")
+
+	err := l.ErrOrNil()
+	if err == nil {
+		t.Fatal("ErrOrNil = nil, want a joined error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "step 1: boom") || !strings.Contains(msg, "step 2: bang") {
+		t.Fatalf("ErrOrNil().Error() = %q, want both recorded errors", msg)
+	}
+}
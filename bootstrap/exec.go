@@ -0,0 +1,224 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// RunOptions configures a Run/RunWith invocation.
+type RunOptions struct {
+	// Dir is the working directory for the subprocess, defaulting to
+	// the caller's own if empty.
+	Dir string
+	// Env, if non-empty, is appended to the subprocess's environment
+	// (on top of the parent's, or on top of EnvAllowlist's selection if
+	// that's set).
+	Env []string
+	// EnvAllowlist, if non-nil, replaces the default of inheriting the
+	// whole parent environment: the subprocess starts with only these
+	// variable names (taking their current values from the parent),
+	// plus whatever Env adds on top. A non-nil empty slice means "no
+	// inherited vars at all, only Env". The default (nil) inherits
+	// everything, matching historical behavior.
+	EnvAllowlist []string
+	// Echo prints the command line to stderr before running it, for
+	// debugging opaque build failures. It's also enabled by setting the
+	// VERBOSE environment variable.
+	Echo bool
+	// Tee, if non-nil, receives a live copy of the subprocess's stdout
+	// and stderr as each chunk arrives, interleaved in whatever order
+	// the two streams actually produce it, in addition to the
+	// RunResult captured in memory. Each write to Tee is flushed
+	// immediately (if Tee supports it) so a process killed mid-run
+	// still leaves a complete partial log. The default (nil) tees
+	// nowhere.
+	Tee io.Writer
+}
+
+// teeOrDiscard wraps dst so every write also goes to opts.Tee, flushing
+// Tee afterward if it exposes a Flush method (e.g. a *bufio.Writer),
+// so a subprocess killed mid-write still leaves a complete log on disk.
+// With no Tee configured, it returns dst unchanged.
+func teeOrDiscard(dst io.Writer, opts RunOptions) io.Writer {
+	if opts.Tee == nil {
+		return dst
+	}
+	return io.MultiWriter(dst, &flushingWriter{opts.Tee})
+}
+
+// flusher is implemented by buffered writers like *bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+type flushingWriter struct {
+	w io.Writer
+}
+
+func (f *flushingWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if fl, ok := f.w.(flusher); ok {
+		if ferr := fl.Flush(); err == nil {
+			err = ferr
+		}
+	}
+	return n, err
+}
+
+// filterEnviron returns "KEY=VALUE" for each name in allowlist that is
+// set in the current process's environment, in allowlist order,
+// skipping names that aren't set.
+func filterEnviron(allowlist []string) []string {
+	env := make([]string, 0, len(allowlist))
+	for _, name := range allowlist {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+// baseEnv returns the environment a subprocess should start from,
+// before opts.Env is layered on top: the full parent environment, or
+// just opts.EnvAllowlist's selection of it when that's set.
+func baseEnv(opts RunOptions) []string {
+	if opts.EnvAllowlist != nil {
+		return filterEnviron(opts.EnvAllowlist)
+	}
+	return os.Environ()
+}
+
+// Run executes name with args and captures its output, using the
+// default RunOptions.
+func Run(name string, args ...string) (RunResult, error) {
+	return RunWith(name, args, RunOptions{})
+}
+
+// RunWith is like Run but accepts RunOptions to control the working
+// directory, environment, and command-line echoing.
+func RunWith(name string, args []string, opts RunOptions) (RunResult, error) {
+	if opts.Echo || os.Getenv("VERBOSE") != "" {
+		fmt.Fprintf(os.Stderr, "$ %s\n", strings.Join(append([]string{name}, args...), " "))
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = opts.Dir
+	if opts.EnvAllowlist != nil || len(opts.Env) > 0 {
+		cmd.Env = append(baseEnv(opts), opts.Env...)
+	}
+	setProcessGroup(cmd)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = teeOrDiscard(&stdout, opts)
+	cmd.Stderr = teeOrDiscard(&stderr, opts)
+
+	runErr := cmd.Run()
+	result := RunResult{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: cmd.ProcessState.ExitCode(),
+	}
+
+	if runErr != nil {
+		if _, ok := runErr.(*exec.ExitError); ok {
+			return result, nil
+		}
+		return result, runErr
+	}
+	return result, nil
+}
+
+// RunContext is like RunWith but ctx governs the subprocess: if ctx is
+// canceled or its deadline passes before the command exits, its whole
+// process group is killed via killProcessGroup and RunContext returns
+// ctx.Err() alongside whatever output was captured before the kill.
+func RunContext(ctx context.Context, name string, args []string, opts RunOptions) (RunResult, error) {
+	if opts.Echo || os.Getenv("VERBOSE") != "" {
+		fmt.Fprintf(os.Stderr, "$ %s\n", strings.Join(append([]string{name}, args...), " "))
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = opts.Dir
+	if opts.EnvAllowlist != nil || len(opts.Env) > 0 {
+		cmd.Env = append(baseEnv(opts), opts.Env...)
+	}
+	setProcessGroup(cmd)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = teeOrDiscard(&stdout, opts)
+	cmd.Stderr = teeOrDiscard(&stderr, opts)
+
+	if err := cmd.Start(); err != nil {
+		return RunResult{}, err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case runErr := <-done:
+		result := RunResult{
+			Stdout:   stdout.String(),
+			Stderr:   stderr.String(),
+			ExitCode: cmd.ProcessState.ExitCode(),
+		}
+		if runErr != nil {
+			if _, ok := runErr.(*exec.ExitError); ok {
+				return result, nil
+			}
+			return result, runErr
+		}
+		return result, nil
+	case <-ctx.Done():
+		_ = killProcessGroup(cmd)
+		<-done
+		return RunResult{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: -1}, ctx.Err()
+	}
+}
+
+// RunOK runs name with args using the default RunOptions and returns an
+// error if it failed to start or exited non-zero, bundling the exit
+// code and trailing stderr into the error so callers don't need to
+// inspect a RunResult themselves.
+func RunOK(name string, args ...string) error {
+	result, err := Run(name, args...)
+	if err != nil {
+		return err
+	}
+	if result.ExitCode != 0 {
+		return fmt.Errorf("%s: exited %d\n%s", name, result.ExitCode, lastLines(result.Stderr, 10))
+	}
+	return nil
+}
+
+// MustRun runs name with args and, on failure, returns an error
+// prefixed with prefix describing what failed. This package never
+// calls os.Exit itself, so it stays safe to embed as a library; a CLI
+// caller that wants MustRun's historical "print and exit" behavior can
+// do so itself, e.g.:
+//
+//	if err := bootstrap.MustRun("build", "cargo", "build"); err != nil {
+//		fmt.Fprintln(os.Stderr, err)
+//		os.Exit(2)
+//	}
+func MustRun(prefix, name string, args ...string) error {
+	if err := RunOK(name, args...); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// lastLines returns at most the last n lines of s, for trimming a
+// failing command's stderr down to the part most likely to explain it.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunContextKillsOnTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := RunContext(ctx, "sleep", []string{"5"}, RunOptions{})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("RunContext err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRunContextSucceedsWithinDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	result, err := RunContext(ctx, "echo", []string{"hi"}, RunOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Stdout != "hi\n" {
+		t.Fatalf("Stdout = %q, want %q", result.Stdout, "hi\n")
+	}
+}
@@ -0,0 +1,46 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunCapturesOutput(t *testing.T) {
+	result, err := Run("echo", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Stdout != "hello\n" {
+		t.Fatalf("Stdout = %q, want %q", result.Stdout, "hello\n")
+	}
+}
+
+func TestRunOKSucceeds(t *testing.T) {
+	if err := RunOK("true"); err != nil {
+		t.Fatalf("RunOK(true) = %v, want nil", err)
+	}
+}
+
+func TestRunOKReportsExitCodeAndStderr(t *testing.T) {
+	err := RunOK("sh", "-c", "echo boom >&2; exit 3")
+	if err == nil {
+		t.Fatal("RunOK = nil error for a failing command, want an error")
+	}
+	if !strings.Contains(err.Error(), "exited 3") || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("RunOK error = %q, want exit code and stderr", err)
+	}
+}
+
+func TestMustRunReturnsErrorInsteadOfExiting(t *testing.T) {
+	if err := MustRun("build", "true"); err != nil {
+		t.Fatalf("MustRun(true) = %v, want nil", err)
+	}
+
+	err := MustRun("build", "sh", "-c", "exit 1")
+	if err == nil {
+		t.Fatal("MustRun = nil error for a failing command, want an error")
+	}
+	if !strings.HasPrefix(err.Error(), "build:") {
+		t.Fatalf("MustRun error = %q, want it prefixed with %q", err, "build:")
+	}
+}
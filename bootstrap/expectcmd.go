@@ -0,0 +1,25 @@
+package bootstrap
+
+import "fmt"
+
+// runExpectCmd runs cmd through the shell in dir and returns its
+// stdout, for a `# expect-cmd:` directive's reference-implementation
+// comparison. It returns an error if the command itself errors, since a
+// broken reference command silently producing empty output would make
+// every test pass against nothing.
+//
+// Security: cmd comes straight from the `.byte` script's own source, so
+// this only belongs in test discovery for a trusted test tree — the
+// same trust boundary the scripts themselves already cross by running
+// through the byte interpreter. Never point discoverTests at a
+// directory containing untrusted scripts.
+func runExpectCmd(cmd, dir string) (string, error) {
+	result, err := RunWith("sh", []string{"-c", cmd}, RunOptions{Dir: dir})
+	if err != nil {
+		return "", fmt.Errorf("running `# expect-cmd: %s`: %w", cmd, err)
+	}
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("`# expect-cmd: %s` exited %d\n%s", cmd, result.ExitCode, lastLines(result.Stderr, 10))
+	}
+	return result.Stdout, nil
+}
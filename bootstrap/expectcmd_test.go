@@ -0,0 +1,41 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsExpectCmdUsesCommandStdoutAsExpected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "echoed.byte"), "# expect-cmd: echo hello\nprint(\"hello\")")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("discoverTests found %d tests, want 1", len(tests))
+	}
+	if tests[0].Expected != "hello\n" {
+		t.Fatalf("Expected = %q, want %q", tests[0].Expected, "hello\n")
+	}
+}
+
+func TestDiscoverTestsExpectCmdRejectsAlongsideOutFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "both.byte"), "# expect-cmd: echo hello\nprint(\"hello\")")
+	writeFile(t, filepath.Join(dir, "both.out"), "hello\n")
+
+	if _, err := discoverTests(dir); err == nil {
+		t.Fatal("discoverTests = nil error for a test with both an .out file and an # expect-cmd directive, want error")
+	}
+}
+
+func TestDiscoverTestsExpectCmdFailsClearlyOnCommandError(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "broken.byte"), "# expect-cmd: exit 1\nprint(\"hello\")")
+
+	if _, err := discoverTests(dir); err == nil {
+		t.Fatal("discoverTests = nil error when the reference command fails, want error")
+	}
+}
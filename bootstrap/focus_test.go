@@ -0,0 +1,23 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRunTestsFocusMode(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.byte"), "# only\nprint(1)")
+	writeFile(t, filepath.Join(dir, "a.out"), "1\n")
+	writeFile(t, filepath.Join(dir, "b.byte"), "print(2)")
+	writeFile(t, filepath.Join(dir, "b.out"), "2\n")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	focused, deselected := focusOnly(tests)
+	if len(focused) != 1 || focused[0].Name != "a" || deselected != 1 {
+		t.Fatalf("focusOnly = %v (deselected %d), want just %q", focused, deselected, "a")
+	}
+}
@@ -0,0 +1,104 @@
+package bootstrap
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GlobOptions configures a Glob call.
+type GlobOptions struct {
+	// MaxDepth limits how many directory levels below root are
+	// descended into, counted in path segments relative to root: 0
+	// matches only files directly in root, 1 additionally matches one
+	// level of subdirectories, and so on. -1 means unlimited, which is
+	// what Glob (as opposed to GlobWithOptions) always uses.
+	MaxDepth int
+	// IgnoreCase matches pattern case-insensitively, e.g. so `*.by`
+	// also matches `FOO.BY`. The default (false) is case-sensitive,
+	// matching how the filesystem actually compares names on Linux.
+	IgnoreCase bool
+}
+
+// Glob returns every path under root matching pattern (a glob using `*`
+// for a single path segment and `**` for any number of segments),
+// relative to root, with no depth limit. Results are sorted lexically,
+// so callers get a stable order regardless of the filesystem's own
+// directory-entry ordering.
+func Glob(root, pattern string) ([]string, error) {
+	return GlobWithOptions(root, pattern, GlobOptions{MaxDepth: -1})
+}
+
+// GlobWithOptions is like Glob but lets the caller bound the search with
+// opts, such as capping recursion depth so a pattern can't run away over
+// a huge tree.
+func GlobWithOptions(root, pattern string, opts GlobOptions) ([]string, error) {
+	re := compileGlob(pattern, opts.IgnoreCase)
+
+	walkRoot := withLongPathPrefix(root)
+	ignoreFiles := map[string][]*regexp.Regexp{}
+	var matches []string
+	err := filepath.WalkDir(walkRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			patterns, err := loadByteIgnore(path)
+			if err != nil {
+				return err
+			}
+			if patterns != nil {
+				ignoreFiles[path] = patterns
+			}
+		}
+		if path == walkRoot {
+			return nil
+		}
+		if byteIgnoreIsIgnored(walkRoot, path, ignoreFiles) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(walkRoot, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if opts.MaxDepth >= 0 && strings.Count(rel, "/") > opts.MaxDepth {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+		if re.MatchString(rel) {
+			matches = append(matches, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// compileGlob turns a glob pattern into a fully anchored regular
+// expression via CompileGlob. Patterns reaching here come from trusted
+// call sites, not arbitrary regex, so a compile failure would mean a
+// bug in CompileGlob itself.
+func compileGlob(pattern string, ignoreCase bool) *regexp.Regexp {
+	re, err := CompileGlob(pattern, GlobCompileOptions{AnchorStart: true, AnchorEnd: true, IgnoreCase: ignoreCase})
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
@@ -0,0 +1,34 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGlobDefaultIsCaseSensitive(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "foo.byte"), "")
+	writeFile(t, filepath.Join(dir, "FOO.BYTE"), "")
+
+	matches, err := Glob(dir, "*.byte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0] != "foo.byte" {
+		t.Fatalf("Glob(*.byte) = %v, want just [foo.byte]", matches)
+	}
+}
+
+func TestGlobIgnoreCaseOption(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "foo.byte"), "")
+	writeFile(t, filepath.Join(dir, "FOO.BYTE"), "")
+
+	matches, err := GlobWithOptions(dir, "*.byte", GlobOptions{IgnoreCase: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("GlobWithOptions(IgnoreCase:true) = %v, want both files", matches)
+	}
+}
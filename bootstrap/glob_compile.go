@@ -0,0 +1,98 @@
+package bootstrap
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// GlobCompileOptions controls how CompileGlob turns a glob pattern into
+// a regular expression.
+type GlobCompileOptions struct {
+	// AnchorStart anchors the expression to the start of the string
+	// with `^`. The default (false) leaves it unanchored.
+	AnchorStart bool
+	// AnchorEnd anchors the expression to the end of the string with
+	// `$`. The default (false) leaves it unanchored.
+	AnchorEnd bool
+	// IgnoreCase compiles the expression case-insensitively.
+	IgnoreCase bool
+}
+
+// globCacheKey identifies a previously compiled pattern/opts pair.
+type globCacheKey struct {
+	pattern string
+	opts    GlobCompileOptions
+}
+
+var globCompileCache sync.Map // map[globCacheKey]*regexp.Regexp
+
+// CompileGlob compiles pattern into a regular expression: `*` matches
+// any run of characters within a single path segment, `**` matches any
+// run of characters including `/`, and `?` matches a single character.
+// A whole `**` segment (bounded by `/` or the start/end of pattern)
+// also matches zero directories, so `"**/*.byte"` matches a file
+// directly in the root as well as one nested arbitrarily deep, and
+// `"a/**/b.byte"` matches `"a/b.byte"` in addition to `"a/x/b.byte"`. A
+// backslash escapes the next character, so `\*`, `\?`, and `\[` match
+// that character literally and `\\` matches a literal backslash; a
+// backslash before any other character likewise matches just that
+// character, and a trailing backslash matches a literal backslash.
+// opts controls anchoring and
+// casing. Results are cached, since the same handful of patterns tend
+// to get compiled repeatedly across a test run.
+func CompileGlob(pattern string, opts GlobCompileOptions) (*regexp.Regexp, error) {
+	key := globCacheKey{pattern, opts}
+	if cached, ok := globCompileCache.Load(key); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	var b strings.Builder
+	if opts.IgnoreCase {
+		b.WriteString("(?i)")
+	}
+	if opts.AnchorStart {
+		b.WriteString("^")
+	}
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				atSegmentStart := i == 0 || runes[i-1] == '/'
+				if atSegmentStart && i+2 < len(runes) && runes[i+2] == '/' {
+					// A "**/" segment matches zero or more whole path
+					// segments, including none at all, so the slash
+					// after it is optional too.
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if opts.AnchorEnd {
+		b.WriteString("$")
+	}
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("compiling glob %q: %w", pattern, err)
+	}
+	globCompileCache.Store(key, re)
+	return re, nil
+}
@@ -0,0 +1,78 @@
+package bootstrap
+
+import "testing"
+
+func TestCompileGlobAnchoring(t *testing.T) {
+	re, err := CompileGlob("*.byte", GlobCompileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString("a/foo.byte") {
+		t.Fatal("unanchored pattern should match as a substring")
+	}
+
+	anchored, err := CompileGlob("*.byte", GlobCompileOptions{AnchorStart: true, AnchorEnd: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anchored.MatchString("a/foo.byte") {
+		t.Fatal("anchored pattern should not match across a path separator")
+	}
+	if !anchored.MatchString("foo.byte") {
+		t.Fatal("anchored pattern should match a single segment")
+	}
+}
+
+func TestCompileGlobIgnoreCase(t *testing.T) {
+	re, err := CompileGlob("FOO.*", GlobCompileOptions{AnchorStart: true, AnchorEnd: true, IgnoreCase: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString("foo.byte") {
+		t.Fatal("IgnoreCase should make the match case-insensitive")
+	}
+}
+
+func TestCompileGlobCaches(t *testing.T) {
+	a, err := CompileGlob("*.go", GlobCompileOptions{AnchorStart: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := CompileGlob("*.go", GlobCompileOptions{AnchorStart: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Fatal("CompileGlob should return the cached *regexp.Regexp for an identical call")
+	}
+}
+
+func TestCompileGlobEscapedMetacharactersMatchLiterally(t *testing.T) {
+	cases := []struct {
+		pattern, name string
+	}{
+		{`a\*b`, "a*b"},
+		{`a\?b`, "a?b"},
+		{`a\[b`, "a[b"},
+		{`a\\b`, `a\b`},
+	}
+	for _, c := range cases {
+		re, err := CompileGlob(c.pattern, GlobCompileOptions{AnchorStart: true, AnchorEnd: true})
+		if err != nil {
+			t.Fatalf("CompileGlob(%q) = %v", c.pattern, err)
+		}
+		if !re.MatchString(c.name) {
+			t.Fatalf("pattern %q should match literal name %q", c.pattern, c.name)
+		}
+	}
+}
+
+func TestCompileGlobEscapedStarDoesNotMatchLikeAWildcard(t *testing.T) {
+	re, err := CompileGlob(`a\*b`, GlobCompileOptions{AnchorStart: true, AnchorEnd: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if re.MatchString("aXXXb") {
+		t.Fatal(`escaped \* should not behave like an unescaped * wildcard`)
+	}
+}
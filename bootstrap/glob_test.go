@@ -0,0 +1,56 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestGlobMaxDepth(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.byte"), "")
+	writeFile(t, filepath.Join(dir, "sub", "b.byte"), "")
+	writeFile(t, filepath.Join(dir, "sub", "nested", "c.byte"), "")
+
+	all, err := Glob(dir, "**/*.byte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 matches unrestricted, got %v", all)
+	}
+
+	root, err := GlobWithOptions(dir, "**/*.byte", GlobOptions{MaxDepth: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a.byte"}; !reflect.DeepEqual(root, want) {
+		t.Fatalf("GlobWithOptions(MaxDepth:0) = %v, want %v", root, want)
+	}
+
+	shallow, err := GlobWithOptions(dir, "**/*.byte", GlobOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(shallow)
+	want := []string{"a.byte", "sub/b.byte"}
+	if !reflect.DeepEqual(shallow, want) {
+		t.Fatalf("GlobWithOptions(MaxDepth:1) = %v, want %v", shallow, want)
+	}
+}
+
+func TestGlobOutputIsSorted(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "z.byte"), "")
+	writeFile(t, filepath.Join(dir, "a.byte"), "")
+	writeFile(t, filepath.Join(dir, "m.byte"), "")
+
+	matches, err := Glob(dir, "*.byte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sort.StringsAreSorted(matches) {
+		t.Fatalf("Glob output not sorted: %v", matches)
+	}
+}
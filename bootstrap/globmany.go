@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// GlobMany is like Glob but searches every directory in roots, returning
+// their matches merged into one sorted, deduplicated list of absolute
+// paths. A path reachable from two overlapping roots (e.g. one root
+// nested inside another) is reported once, not once per root.
+func GlobMany(roots []string, pattern string) ([]string, error) {
+	seen := map[string]bool{}
+	var matches []string
+	for _, root := range roots {
+		rel, err := Glob(root, pattern)
+		if err != nil {
+			return nil, err
+		}
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rel {
+			abs := filepath.Join(absRoot, r)
+			if seen[abs] {
+				continue
+			}
+			seen[abs] = true
+			matches = append(matches, abs)
+		}
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// b.byte sits directly in root, so this also exercises "**/*.byte"
+// matching a file with zero intervening directories (see CompileGlob).
+func TestGlobManyDedupesOverlappingRoots(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "sub", "a.byte"), "")
+	writeFile(t, filepath.Join(root, "b.byte"), "")
+
+	matches, err := GlobMany([]string{root, filepath.Join(root, "sub")}, "**/*.byte")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantA, err := filepath.Abs(filepath.Join(root, "sub", "a.byte"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantB, err := filepath.Abs(filepath.Join(root, "b.byte"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(matches) != 2 {
+		t.Fatalf("GlobMany = %v, want 2 deduplicated matches", matches)
+	}
+	found := map[string]bool{matches[0]: true, matches[1]: true}
+	if !found[wantA] || !found[wantB] {
+		t.Fatalf("GlobMany = %v, want %v and %v", matches, wantA, wantB)
+	}
+}
+
+func TestGlobManyMultipleDisjointRoots(t *testing.T) {
+	rootA := t.TempDir()
+	rootB := t.TempDir()
+	writeFile(t, filepath.Join(rootA, "a.byte"), "")
+	writeFile(t, filepath.Join(rootB, "b.byte"), "")
+
+	matches, err := GlobMany([]string{rootA, rootB}, "*.byte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("GlobMany = %v, want 2 matches", matches)
+	}
+}
@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+)
+
+// gzExt is the suffix a compressed sibling of an expected-output file
+// carries, e.g. "hello.out.gz" alongside "hello.out".
+const gzExt = ".gz"
+
+// ReadTextMaybeGz reads filename, falling back to its gzip-compressed
+// sibling filename+".gz" (decompressing it) when the plain file is
+// absent. The plain file always wins when both exist. A missing
+// filename and missing filename+".gz" is not an error: it returns
+// ("", nil), matching ReadText's leniency. The decompressed size is
+// capped by MaxReadBytes, same as ReadText.
+func ReadTextMaybeGz(filename string) (string, error) {
+	if fileExists(filename) {
+		return ReadText(filename)
+	}
+
+	data, err := os.ReadFile(filename + gzExt)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	decompressed, err := readAllWithLimit(zr, MaxReadBytes, filename+gzExt)
+	if err != nil {
+		return "", err
+	}
+	return string(decompressed), nil
+}
+
+// WriteTextGz gzip-compresses text and writes it to filename+".gz"
+// atomically, for golden-file writers that want to keep large expected
+// outputs out of the repo uncompressed.
+func WriteTextGz(filename, text string) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(text)); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writeFileAtomic(filename+gzExt, buf.Bytes())
+}
@@ -0,0 +1,66 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReadTextMaybeGzPrefersPlainFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hello.out")
+	writeFile(t, path, "plain\n")
+	if err := WriteTextGz(path, "compressed\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadTextMaybeGz(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "plain\n" {
+		t.Fatalf("ReadTextMaybeGz = %q, want the plain file's contents", got)
+	}
+}
+
+func TestReadTextMaybeGzFallsBackToCompressed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hello.out")
+	if err := WriteTextGz(path, "compressed\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadTextMaybeGz(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "compressed\n" {
+		t.Fatalf("ReadTextMaybeGz = %q, want the decompressed contents", got)
+	}
+}
+
+func TestReadTextMaybeGzMissingIsEmpty(t *testing.T) {
+	dir := t.TempDir()
+	got, err := ReadTextMaybeGz(filepath.Join(dir, "missing.out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "" {
+		t.Fatalf("ReadTextMaybeGz = %q, want empty string", got)
+	}
+}
+
+func TestDiscoverTestsHonorsGzCompressedExpected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "big.byte"), "")
+	if err := WriteTextGz(filepath.Join(dir, "big.out"), "hi\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || tests[0].Expected != "hi\n" {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
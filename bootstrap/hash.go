@@ -0,0 +1,64 @@
+// Package bootstrap provides the tooling used to build and test the byte
+// compiler: locating the project root, building the interpreter binary,
+// and running the script-based test suite against it.
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// HashTree walks the tree rooted at root in sorted order and returns a
+// hex digest covering the relative path and contents of every file for
+// which include returns true. A nil include hashes every file.
+//
+// File contents are streamed into the digest rather than read fully into
+// memory, so HashTree stays cheap even over large trees.
+func HashTree(root string, include func(path string) bool) (string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if include != nil && !include(rel) {
+			return nil
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, rel := range paths {
+		io.WriteString(h, rel)
+		h.Write([]byte{0})
+
+		f, err := os.Open(filepath.Join(root, rel))
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
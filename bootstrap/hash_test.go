@@ -0,0 +1,65 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHashTreeStableAndSensitiveToChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.txt"), "hello")
+	writeFile(t, filepath.Join(dir, "sub", "b.txt"), "world")
+
+	h1, err := HashTree(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, err := HashTree(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 != h2 {
+		t.Fatalf("HashTree not stable across runs: %q != %q", h1, h2)
+	}
+
+	writeFile(t, filepath.Join(dir, "sub", "b.txt"), "world!")
+	h3, err := HashTree(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h3 == h1 {
+		t.Fatal("HashTree did not change after editing an included file")
+	}
+}
+
+func TestHashTreeInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.rs"), "fn main() {}")
+	writeFile(t, filepath.Join(dir, "a.log"), "noise")
+
+	onlyRs := func(path string) bool { return filepath.Ext(path) == ".rs" }
+
+	h1, err := HashTree(dir, onlyRs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "a.log"), "more noise")
+	h2, err := HashTree(dir, onlyRs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 != h2 {
+		t.Fatal("HashTree changed when an excluded file changed")
+	}
+}
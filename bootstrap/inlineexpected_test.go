@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsUsesInlineExpectedBlock(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "inline.byte"), "print(\"hello\")\nprint(\"world\")\n# --- expected ---\n# hello\n# world\n")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("discoverTests found %d tests, want 1", len(tests))
+	}
+	if tests[0].Expected != "hello\nworld\n" {
+		t.Fatalf("Expected = %q, want %q", tests[0].Expected, "hello\nworld\n")
+	}
+}
+
+func TestDiscoverTestsWithoutInlineBlockOrOutFileIsNotATest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "helper.byte"), "print(\"hello\")\n")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 0 {
+		t.Fatalf("discoverTests found %d tests, want 0 for a script with no expectation", len(tests))
+	}
+}
+
+func TestDiscoverTestsInlineBlockRejectsNonCommentLine(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "bad.byte"), "print(\"hello\")\n# --- expected ---\nhello\n")
+
+	if _, err := discoverTests(dir); err == nil {
+		t.Fatal("discoverTests = nil error for an inline block with a non-comment line, want error")
+	}
+}
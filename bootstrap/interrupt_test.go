@@ -0,0 +1,29 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunTestsStopsLaunchingAfterContextCanceled(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b", "c"} {
+		writeScript(t, dir, name+".byte", "")
+		if err := os.WriteFile(filepath.Join(dir, name+".out"), []byte(""), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	summary := RunTests(TestOptions{Dir: dir, BinPath: "true", Context: ctx})
+	if !summary.Interrupted {
+		t.Fatal("expected Interrupted to be set")
+	}
+	if summary.Total != 0 {
+		t.Fatalf("Total = %d, want 0 since the context was already canceled", summary.Total)
+	}
+}
@@ -0,0 +1,141 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxReadBytes caps how much ReadText and ReadTextMaybeGz will read
+// from a single file before failing with a clear "file too large"
+// error, instead of risking an OOM on an accidentally-committed giant
+// fixture. Override it per call with ReadTextWithLimit.
+var MaxReadBytes int64 = 64 << 20 // 64 MiB
+
+// readAllWithLimit reads all of r, failing with a "file too large"
+// error naming name if more than maxBytes come through — checked by
+// reading one byte past the limit rather than trusting a reported size
+// up front, so it works for streams (like a gzip decompressor) that
+// don't know their output size in advance.
+func readAllWithLimit(r io.Reader, maxBytes int64, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%s: file too large (limit %d bytes)", name, maxBytes)
+	}
+	return data, nil
+}
+
+// ReadText reads filename and returns its contents as a string, using
+// MaxReadBytes as the size limit. A missing file is not an error: it
+// returns ("", nil), so callers can treat an absent expectations file
+// the same as an empty one.
+func ReadText(filename string) (string, error) {
+	return ReadTextWithLimit(filename, MaxReadBytes)
+}
+
+// ReadTextWithLimit is like ReadText but enforces maxBytes instead of
+// the package-level MaxReadBytes default.
+func ReadTextWithLimit(filename string, maxBytes int64) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	data, err := readAllWithLimit(f, maxBytes, filename)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Lines splits s into lines, dropping the final empty element that
+// strings.Split produces for trailing-newline-terminated text.
+func Lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// JoinLines is the inverse of Lines: it joins lines with "\n" and adds
+// a trailing newline, so JoinLines(Lines(s)) == s for any s that either
+// is empty or already ends in "\n".
+func JoinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// CountLines returns the number of lines in input, matching what
+// len(Lines(input)) would return but in a single pass with no
+// allocation, for callers (progress reporting, artifacts) that only
+// need the count.
+func CountLines(input string) int {
+	if input == "" {
+		return 0
+	}
+	count := strings.Count(input, "\n")
+	if !strings.HasSuffix(input, "\n") {
+		count++
+	}
+	return count
+}
+
+// CountRunes returns the number of runes in input.
+func CountRunes(input string) int {
+	return utf8.RuneCountInString(input)
+}
+
+// writeFileAtomic writes data to filename by writing to a temp file in
+// the same directory and renaming it into place, so a crash or
+// concurrent reader never observes a partially written file.
+func writeFileAtomic(filename string, data []byte) error {
+	dir := filepath.Dir(filename)
+	tmp, err := os.CreateTemp(dir, filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, filename)
+}
+
+// ReadLines reads filename and splits it into lines using Lines. A
+// missing file returns (nil, nil), matching ReadText's leniency.
+func ReadLines(filename string) ([]string, error) {
+	text, err := ReadText(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Lines(text), nil
+}
+
+// WriteLines joins lines with JoinLines (adding a trailing newline) and
+// writes the result to filename atomically.
+func WriteLines(filename string, lines []string) error {
+	return writeFileAtomic(filename, []byte(JoinLines(lines)))
+}
@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadWriteLinesRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lines.txt")
+
+	want := []string{"a", "b", "c"}
+	if err := WriteLines(path, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadLines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadLines = %v, want %v", got, want)
+	}
+}
+
+func TestReadLinesMissingFile(t *testing.T) {
+	got, err := ReadLines(filepath.Join(t.TempDir(), "missing.txt"))
+	if err != nil || got != nil {
+		t.Fatalf("ReadLines(missing) = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestCountLinesMatchesLines(t *testing.T) {
+	cases := []string{"", "a", "a\n", "a\nb", "a\nb\n", "a\r\nb\r\n", "a\nb\r\nc"}
+	for _, s := range cases {
+		if got, want := CountLines(s), len(Lines(s)); got != want {
+			t.Errorf("CountLines(%q) = %d, want %d (len(Lines))", s, got, want)
+		}
+	}
+}
+
+func TestCountRunes(t *testing.T) {
+	if got := CountRunes("héllo"); got != 5 {
+		t.Fatalf("CountRunes(héllo) = %d, want 5", got)
+	}
+	if got := CountRunes(""); got != 0 {
+		t.Fatalf("CountRunes(\"\") = %d, want 0", got)
+	}
+}
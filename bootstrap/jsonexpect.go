@@ -0,0 +1,137 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ax-lab/byte/diff"
+)
+
+// JSONCompareOptions controls how a test's JSON-array expected output
+// (see ScriptTest.JSONExpected) is compared against the program's
+// actual stdout lines.
+type JSONCompareOptions struct {
+	// Lenient allows numeric/string equivalence ("1" matches 1) and
+	// normalizes floats with no fractional part (1.0 -> "1") before
+	// comparing. The default compares each line against the value's
+	// plain fmt.Sprint text; a decoded JSON string prints without its
+	// quotes, so `1` and `"1"` both expect the line 1 there too, but a
+	// large whole number such as 100000000 prints as 1e+08.
+	Lenient bool
+}
+
+// jsonText renders a decoded JSON value as CheckResult expects it to
+// appear on stdout, honoring opts.Lenient's numeric/string coercion.
+func jsonText(v interface{}, opts JSONCompareOptions) string {
+	if !opts.Lenient {
+		return fmt.Sprint(v)
+	}
+	if f, ok := v.(float64); ok {
+		if f == float64(int64(f)) {
+			return strconv.FormatInt(int64(f), 10)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// checkJSONExpected compares actual's lines against test's JSON-array
+// expectation positionally, returning a *JSONMismatchError describing
+// every mismatched line (not just the first) when any are found. When
+// the line counts differ it returns a plain error naming both counts
+// instead, without comparing any line.
+func checkJSONExpected(test *ScriptTest, actual string) error {
+	lines := strings.Split(strings.TrimRight(actual, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		lines = nil
+	}
+
+	if len(lines) != len(test.JSONExpected) {
+		return fmt.Errorf("%s: expected %d lines, got %d", test.Name, len(test.JSONExpected), len(lines))
+	}
+
+	var mismatches []JSONLineMismatch
+	expectedLines := make([]string, len(test.JSONExpected))
+	for i, want := range test.JSONExpected {
+		wantText := jsonText(want, test.JSONCompare)
+		expectedLines[i] = wantText
+		got := lines[i]
+
+		if got == wantText {
+			continue
+		}
+		if test.JSONCompare.Lenient && fmt.Sprint(want) == got {
+			continue
+		}
+		mismatches = append(mismatches, JSONLineMismatch{
+			Line:     i + 1,
+			Expected: jsonDescribe(want),
+			Actual:   got,
+		})
+	}
+	if mismatches == nil {
+		return nil
+	}
+
+	return &JSONMismatchError{
+		Test:       test.Name,
+		Mismatches: mismatches,
+		Blocks:     diff.Compare(asTrailingNewlines(expectedLines), asTrailingNewlines(lines)),
+	}
+}
+
+// asTrailingNewlines reformats lines (without trailing newlines) the
+// way diff.Compare expects them, for use as a secondary line-diff view
+// alongside JSONMismatchError's per-index report.
+func asTrailingNewlines(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = l + "\n"
+	}
+	return out
+}
+
+// JSONLineMismatch describes one line that didn't match its expected
+// JSON value.
+type JSONLineMismatch struct {
+	Line     int
+	Expected string
+	Actual   string
+}
+
+// JSONMismatchError reports every line that failed a JSON-array
+// expectation check, along with Blocks: a line-level diff between the
+// expected and actual output, for callers that want to render it as a
+// unified diff rather than the per-index report.
+type JSONMismatchError struct {
+	Test       string
+	Mismatches []JSONLineMismatch
+	Blocks     []diff.DiffBlock
+}
+
+func (e *JSONMismatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d line(s) mismatched JSON expectation:\n", e.Test, len(e.Mismatches))
+	for _, m := range e.Mismatches {
+		fmt.Fprintf(&b, "  line %d: expected %s, got %q\n", m.Line, m.Expected, m.Actual)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// jsonDescribe formats a decoded JSON value with its type, for clearer
+// mismatch messages than a bare value would give (e.g. distinguishing
+// the number 42 from the string "42").
+func jsonDescribe(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return fmt.Sprintf("string %q", val)
+	case float64:
+		return fmt.Sprintf("number %v", val)
+	case bool:
+		return fmt.Sprintf("bool %v", val)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
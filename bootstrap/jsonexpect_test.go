@@ -0,0 +1,63 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverTestsJSONExpected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "nums.byte"), "")
+	writeFile(t, filepath.Join(dir, "nums.out.json"), `[1, "two", 3.0]`)
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || len(tests[0].JSONExpected) != 3 {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
+
+func TestCheckResultJSONLenient(t *testing.T) {
+	test := &ScriptTest{Name: "t", JSONExpected: []interface{}{1.0, 3.0}, JSONCompare: JSONCompareOptions{Lenient: true}}
+	result := RunResult{Stdout: "1\n3\n"}
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("lenient numeric comparison should pass: %v", err)
+	}
+}
+
+func TestCheckResultJSONMismatchReportsEveryLine(t *testing.T) {
+	test := &ScriptTest{Name: "t", JSONExpected: []interface{}{1.0, 2.0, 3.0}}
+	result := RunResult{Stdout: "9\n2\n9\n"}
+	err := CheckResult(test, result)
+	if err == nil {
+		t.Fatal("expected mismatch")
+	}
+	mismatch, ok := err.(*JSONMismatchError)
+	if !ok {
+		t.Fatalf("err = %T, want *JSONMismatchError", err)
+	}
+	if len(mismatch.Mismatches) != 2 {
+		t.Fatalf("Mismatches = %v, want 2 entries (lines 1 and 3)", mismatch.Mismatches)
+	}
+	if mismatch.Mismatches[0].Line != 1 || mismatch.Mismatches[1].Line != 3 {
+		t.Fatalf("Mismatches = %v, want lines 1 and 3", mismatch.Mismatches)
+	}
+	if len(mismatch.Blocks) == 0 {
+		t.Fatal("Blocks should hold a line-level diff for the secondary view")
+	}
+}
+
+func TestCheckResultJSONMismatchReportsIndexAndType(t *testing.T) {
+	test := &ScriptTest{Name: "t", JSONExpected: []interface{}{42.0}}
+	result := RunResult{Stdout: "43\n"}
+	err := CheckResult(test, result)
+	if err == nil {
+		t.Fatal("expected mismatch")
+	}
+	if got := err.Error(); !strings.Contains(got, "number 42") {
+		t.Fatalf("error %q should describe the expected JSON type", got)
+	}
+}
@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsHonorsCustomJSONExts(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "byte-test.json"), `{"json_exts": ["json"]}`)
+	writeFile(t, filepath.Join(dir, "nums.byte"), "")
+	writeFile(t, filepath.Join(dir, "nums.json"), `[1, 2, 3]`)
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("got %d tests, want 1", len(tests))
+	}
+	if len(tests[0].JSONExpected) != 3 {
+		t.Fatalf("JSONExpected = %v, want 3 elements", tests[0].JSONExpected)
+	}
+}
+
+func TestDiscoverTestsRejectsMultipleExpectedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "byte-test.json"), `{"json_exts": ["json"]}`)
+	writeFile(t, filepath.Join(dir, "dup.byte"), "")
+	writeFile(t, filepath.Join(dir, "dup.out"), "")
+	writeFile(t, filepath.Join(dir, "dup.json"), `[]`)
+
+	if _, err := discoverTests(dir); err == nil {
+		t.Fatal("discoverTests = nil error, want a conflict error")
+	}
+}
@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ReadJsonLines streams a top-level JSON array from filename, decoding
+// one element at a time and invoking onItem for each, instead of
+// unmarshaling the whole array into memory at once. This keeps memory
+// bounded when a `.out.json` expectation file holds a huge golden
+// array. It returns a descriptive error if the top-level value isn't an
+// array, or if decoding any element or onItem fails.
+func ReadJsonLines(filename string, onItem func(item any) error) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("%s: reading top-level token: %w", filename, err)
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != '[' {
+		return fmt.Errorf("%s: expected a top-level JSON array, got %v", filename, tok)
+	}
+
+	for dec.More() {
+		var item any
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("%s: decoding array element: %w", filename, err)
+		}
+		if err := onItem(item); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("%s: reading closing token: %w", filename, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package bootstrap
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadJsonLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "expected.out.json")
+	writeFile(t, path, `[1, "two", {"three": 3}]`)
+
+	var items []any
+	err := ReadJsonLines(path, func(item any) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3: %v", len(items), items)
+	}
+}
+
+func TestReadJsonLinesRejectsNonArray(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "expected.out.json")
+	writeFile(t, path, `{"not": "an array"}`)
+
+	err := ReadJsonLines(path, func(item any) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error for a non-array top-level value")
+	}
+}
+
+func TestReadJsonLinesPropagatesCallbackError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "expected.out.json")
+	writeFile(t, path, `[1, 2, 3]`)
+
+	calls := 0
+	err := ReadJsonLines(path, func(item any) error {
+		calls++
+		if calls == 2 {
+			return fmt.Errorf("stop here")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the callback's error to propagate")
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2 (stopped at second element)", calls)
+	}
+}
@@ -0,0 +1,72 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ax-lab/byte/diff"
+)
+
+// FailureJSON is one failed test's entry in a JSON test summary: its
+// name, the interpreter's exit code, and the line-level diff behind the
+// mismatch when there is one (e.g. not for a bare run error).
+type FailureJSON struct {
+	Name     string           `json:"name"`
+	ExitCode int              `json:"exitCode"`
+	Diff     []diff.DiffBlock `json:"diff,omitempty"`
+}
+
+// failureJSON builds test's FailureJSON entry, pulling diff blocks out
+// of whichever mismatch error type it carries, if any.
+func failureJSON(test *ScriptTest) FailureJSON {
+	entry := FailureJSON{Name: test.Name, ExitCode: test.ExitCode}
+	switch err := test.Err.(type) {
+	case *MismatchError:
+		entry.Diff = err.Blocks
+	case *JSONMismatchError:
+		entry.Diff = err.Blocks
+	}
+	return entry
+}
+
+// jsonSummary is the on-disk shape written by WriteJSONSummary.
+type jsonSummary struct {
+	Total      int           `json:"total"`
+	Passed     int           `json:"passed"`
+	Failed     int           `json:"failed"`
+	Skipped    int           `json:"skipped"`
+	XFailed    int           `json:"xfailed"`
+	XPassed    int           `json:"xpassed"`
+	DurationMs int64         `json:"durationMs"`
+	Failures   []FailureJSON `json:"failures"`
+}
+
+// WriteJSONSummary writes summary (and how long the run took) as a
+// machine-readable JSON object to path, atomically. The numbers match
+// exactly what RunTests printed. An empty run still writes a valid JSON
+// object with a zero-length failures array rather than null.
+func WriteJSONSummary(path string, summary TestSummary, duration time.Duration) error {
+	out := jsonSummary{
+		Total:      summary.Total,
+		Passed:     summary.Passed,
+		Failed:     summary.Failed,
+		Skipped:    summary.Skipped,
+		XFailed:    summary.XFailed,
+		XPassed:    summary.XPassed,
+		DurationMs: duration.Milliseconds(),
+		Failures:   []FailureJSON{},
+	}
+	for i := range summary.Results {
+		test := &summary.Results[i]
+		if test.Skipped || test.Passed || test.XFail {
+			continue
+		}
+		out.Failures = append(out.Failures, failureJSON(test))
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
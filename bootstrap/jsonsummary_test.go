@@ -0,0 +1,57 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteJSONSummaryEmptyRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	if err := WriteJSONSummary(path, TestSummary{}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("WriteJSONSummary wrote invalid JSON: %v", err)
+	}
+	failures, ok := out["failures"].([]interface{})
+	if !ok || len(failures) != 0 {
+		t.Fatalf("failures = %v, want an empty array", out["failures"])
+	}
+}
+
+func TestWriteJSONSummaryIncludesFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	summary := TestSummary{
+		Total:  2,
+		Passed: 1,
+		Failed: 1,
+		Results: []ScriptTest{
+			{Name: "ok", Passed: true},
+			{Name: "bad", Passed: false, ExitCode: 1, Err: &MismatchError{Test: "bad"}},
+		},
+	}
+	if err := WriteJSONSummary(path, summary, 5*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out jsonSummary
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.DurationMs != 5 || len(out.Failures) != 1 || out.Failures[0].Name != "bad" {
+		t.Fatalf("unexpected summary: %+v", out)
+	}
+}
@@ -0,0 +1,126 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TestInfo summarizes one discovered `.byte` script without running it
+// or loading its full expectation — the read-only counterpart to
+// discoverTests, for tooling (editors, dashboards) that want to show a
+// test explorer without paying for execution.
+type TestInfo struct {
+	// Path is the full path to the `.byte` script.
+	Path string
+	// Name is Path relative to the scanned directory, without the
+	// `.byte` extension. For a script in a subdirectory it keeps that
+	// subdirectory, unlike ScriptTest.Name, which is the base name only.
+	Name string
+	// Kind describes the expected-output this test has: "text" for a
+	// `.out` file or an `# expect-cmd` directive, "json" for a
+	// `.out.json` file, "inline" for an
+	// expected-output block embedded in the script itself, or "none"
+	// for a script with no expectation yet (createmissing would need
+	// to run it first). It doesn't consult a directory's configured
+	// extra JSON extensions, unlike discoverTests.
+	Kind string
+	// Skipped and SkipReason mirror ScriptTest's fields, read from the
+	// same `# skip` directive or `.skip` sidecar.
+	Skipped    bool
+	SkipReason string
+	// Err is set when reading the script's directives failed (e.g. an
+	// invalid `# compare:` value), the per-script counterpart to
+	// discoverTests' error return — a broken script doesn't keep
+	// ListTests from reporting every other one it found.
+	Err error
+}
+
+// ListTests walks dir for `.byte` scripts the same way discoverTests
+// does, but only reads each one's directives and checks for an
+// expected-output file; it never loads or runs a test's full
+// expectation, so it's cheap enough for an editor to call on every
+// keystroke.
+func ListTests(dir string) ([]TestInfo, error) {
+	var infos []TestInfo
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() || filepath.Ext(path) != scriptExt {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+		info := TestInfo{
+			Path: path,
+			Name: strings.TrimSuffix(rel, scriptExt),
+		}
+
+		skipped, reason, err := skipDirective(path)
+		if err != nil {
+			info.Err = err
+			infos = append(infos, info)
+			return nil
+		}
+		info.Skipped, info.SkipReason = skipped, reason
+
+		if _, _, err := compareDirective(path); err != nil {
+			info.Err = err
+			infos = append(infos, info)
+			return nil
+		}
+
+		kind, err := expectedKind(path)
+		if err != nil {
+			info.Err = err
+			infos = append(infos, info)
+			return nil
+		}
+		info.Kind = kind
+
+		infos = append(infos, info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// expectedKind reports what kind of expected-output scriptPath has:
+// "text" for a `.out` (optionally gzipped) file or an `# expect-cmd`
+// directive, "json" for a `.out.json` file, "inline" for an
+// expected-output block embedded in the script, or "none" if it has
+// none of those yet.
+func expectedKind(scriptPath string) (string, error) {
+	base := strings.TrimSuffix(scriptPath, scriptExt)
+	switch {
+	case fileExists(base+expectedExt) || fileExists(base+expectedExt+gzExt):
+		return "text", nil
+	case fileExists(base + jsonExpectedExt):
+		return "json", nil
+	}
+
+	cmd, err := expectCmdDirective(scriptPath)
+	if err != nil {
+		return "", err
+	}
+	if cmd != "" {
+		return "text", nil
+	}
+
+	_, ok, err := inlineExpectedBlock(scriptPath)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return "inline", nil
+	}
+	return "none", nil
+}
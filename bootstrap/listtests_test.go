@@ -0,0 +1,83 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestListTestsReportsMixedStatuses(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "text.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "text.out"), "1\n")
+
+	writeFile(t, filepath.Join(dir, "json.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "json.out.json"), "[1]")
+
+	writeFile(t, filepath.Join(dir, "inline.byte"), "print(1)\n# --- expected ---\n# 1")
+
+	writeFile(t, filepath.Join(dir, "skipped.byte"), "# skip: not ready\nprint(1)")
+
+	writeFile(t, filepath.Join(dir, "nooutput.byte"), "print(1)")
+
+	writeFile(t, filepath.Join(dir, "broken.byte"), "# compare: bogus-mode\nprint(1)")
+
+	infos, err := ListTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := map[string]TestInfo{}
+	for _, info := range infos {
+		byName[info.Name] = info
+	}
+
+	if len(byName) != 6 {
+		t.Fatalf("got %d test(s), want 6: %+v", len(byName), infos)
+	}
+	if got := byName["text"].Kind; got != "text" {
+		t.Fatalf("text.Kind = %q, want %q", got, "text")
+	}
+	if got := byName["json"].Kind; got != "json" {
+		t.Fatalf("json.Kind = %q, want %q", got, "json")
+	}
+	if got := byName["nooutput"].Kind; got != "none" {
+		t.Fatalf("nooutput.Kind = %q, want %q", got, "none")
+	}
+	if skipped := byName["skipped"]; !skipped.Skipped || skipped.SkipReason != "not ready" {
+		t.Fatalf("skipped = %+v, want Skipped with reason %q", skipped, "not ready")
+	}
+	if broken := byName["broken"]; broken.Err == nil {
+		t.Fatalf("broken.Err = nil, want an error naming the bad compare directive")
+	}
+}
+
+func TestListTestsRegionMarkersAloneAreNotAnInlineExpectation(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "regionmarkers.byte"), "print(1)\n<<<BEGIN>>>\n1\n<<<END>>>\n")
+
+	infos, err := ListTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 {
+		t.Fatalf("got %d test(s), want 1", len(infos))
+	}
+	if infos[0].Kind != "none" {
+		t.Fatalf("Kind = %q, want %q since region markers alone aren't an inline-expected block", infos[0].Kind, "none")
+	}
+}
+
+func TestListTestsDoesNotRunAnything(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "t.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "t.out"), "1\n")
+
+	infos, err := ListTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].Kind != "text" {
+		t.Fatalf("unexpected result: %+v", infos)
+	}
+}
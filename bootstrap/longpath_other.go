@@ -0,0 +1,9 @@
+//go:build !windows
+
+package bootstrap
+
+// withLongPathPrefix is a no-op outside Windows, which has no MAX_PATH
+// limitation to work around.
+func withLongPathPrefix(root string) string {
+	return root
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package bootstrap
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// withLongPathPrefix prefixes root with the `\\?\` extended-length form
+// so WalkDir can handle paths exceeding MAX_PATH, which matters for
+// Glob on CI agents with deep checkout directories. UNC paths use the
+// `\\?\UNC\` form instead. root is left untouched if it's already
+// extended-length.
+func withLongPathPrefix(root string) string {
+	if strings.HasPrefix(root, `\\?\`) {
+		return root
+	}
+	if strings.HasPrefix(root, `\\`) {
+		return `\\?\UNC\` + root[2:]
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return root
+	}
+	return `\\?\` + abs
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package bootstrap
+
+import "testing"
+
+func TestWithLongPathPrefixUNC(t *testing.T) {
+	got := withLongPathPrefix(`\\server\share\dir`)
+	want := `\\?\UNC\server\share\dir`
+	if got != want {
+		t.Fatalf("withLongPathPrefix = %q, want %q", got, want)
+	}
+}
+
+func TestWithLongPathPrefixAlreadyExtended(t *testing.T) {
+	path := `\\?\C:\already\extended`
+	if got := withLongPathPrefix(path); got != path {
+		t.Fatalf("withLongPathPrefix = %q, want unchanged %q", got, path)
+	}
+}
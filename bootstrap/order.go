@@ -0,0 +1,91 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// firstFailedPrereq returns the first name in after that isn't marked
+// passed in the given map, or "" if every prerequisite passed. Since
+// orderByDependencies guarantees prerequisites run first, a missing or
+// false entry means that test failed, was skipped, or was itself
+// blocked.
+func firstFailedPrereq(after []string, passed map[string]bool) string {
+	for _, dep := range after {
+		if !passed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// orderByDependencies topologically sorts tests so that every test
+// runs after each name in its After list, using Kahn's algorithm with
+// ties broken by the tests' original (discovery) order. It returns an
+// error describing the cycle if the After edges don't form a DAG.
+//
+// Dependencies constrain ordering; they don't force serialization of
+// otherwise-unrelated tests, so any order consistent with the
+// constraints is valid.
+func orderByDependencies(tests []*ScriptTest) ([]*ScriptTest, error) {
+	byName := make(map[string]*ScriptTest, len(tests))
+	indexOf := make(map[string]int, len(tests))
+	for i, t := range tests {
+		byName[t.Name] = t
+		indexOf[t.Name] = i
+	}
+
+	indegree := make(map[string]int, len(tests))
+	dependents := make(map[string][]string, len(tests))
+	for _, t := range tests {
+		for _, dep := range t.After {
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			indegree[t.Name]++
+			dependents[dep] = append(dependents[dep], t.Name)
+		}
+	}
+
+	var ready []string
+	for _, t := range tests {
+		if indegree[t.Name] == 0 {
+			ready = append(ready, t.Name)
+		}
+	}
+
+	var ordered []*ScriptTest
+	for len(ready) > 0 {
+		// Pick the readiest test with the smallest original index, so
+		// output order stays as close to discovery order as the
+		// constraints allow.
+		best := 0
+		for i := 1; i < len(ready); i++ {
+			if indexOf[ready[i]] < indexOf[ready[best]] {
+				best = i
+			}
+		}
+		name := ready[best]
+		ready = append(ready[:best], ready[best+1:]...)
+
+		ordered = append(ordered, byName[name])
+		for _, dependent := range dependents[name] {
+			indegree[dependent]--
+			if indegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) != len(tests) {
+		var stuck []string
+		for _, t := range tests {
+			if indegree[t.Name] > 0 {
+				stuck = append(stuck, t.Name)
+			}
+		}
+		return nil, fmt.Errorf("cycle in test `# after` dependencies involving: %s", strings.Join(stuck, ", "))
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunTestsSkipsBlockedWhenPrereqFails(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "setup.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "setup.out"), "wrong\n")
+	writeFile(t, filepath.Join(dir, "uses_setup.byte"), "# after: setup\nprint(2)")
+	writeFile(t, filepath.Join(dir, "uses_setup.out"), "2\n")
+
+	var buf bytes.Buffer
+	summary := RunTests(TestOptions{Dir: dir, Output: &buf})
+
+	if summary.Failed != 1 || summary.Skipped != 1 {
+		t.Fatalf("summary = %+v, want 1 failed (setup) and 1 skipped (blocked)", summary)
+	}
+
+	var blocked *ScriptTest
+	for i := range summary.Results {
+		if summary.Results[i].Name == "uses_setup" {
+			blocked = &summary.Results[i]
+		}
+	}
+	if blocked == nil || !blocked.Skipped {
+		t.Fatalf("uses_setup should be skipped as blocked, got %+v", blocked)
+	}
+}
+
+func TestOrderByDependenciesDetectsCycle(t *testing.T) {
+	a := &ScriptTest{Name: "a", After: []string{"b"}}
+	b := &ScriptTest{Name: "b", After: []string{"a"}}
+
+	if _, err := orderByDependencies([]*ScriptTest{a, b}); err == nil {
+		t.Fatal("orderByDependencies = nil error for a cyclic graph, want an error")
+	}
+}
+
+func TestOrderByDependenciesRunsPrereqFirst(t *testing.T) {
+	a := &ScriptTest{Name: "a"}
+	b := &ScriptTest{Name: "b", After: []string{"a"}}
+
+	ordered, err := orderByDependencies([]*ScriptTest{b, a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ordered) != 2 || ordered[0].Name != "a" || ordered[1].Name != "b" {
+		t.Fatalf("ordered = %v, want [a, b]", ordered)
+	}
+}
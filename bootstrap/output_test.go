@@ -0,0 +1,29 @@
+package bootstrap
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunTestsWritesToCustomOutput(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.byte"), "")
+
+	var buf bytes.Buffer
+	summary := RunTests(TestOptions{Dir: dir, Output: &buf})
+
+	got := buf.String()
+	if summary.Total != 0 {
+		t.Fatalf("Total = %d, want 0 (no .out sidecar)", summary.Total)
+	}
+	if !strings.Contains(got, "0 tests executed") {
+		t.Fatalf("output = %q, want it to report zero tests executed", got)
+	}
+}
+
+func TestRunTestsDefaultsOutputToStdout(t *testing.T) {
+	dir := t.TempDir()
+	RunTests(TestOptions{Dir: dir})
+}
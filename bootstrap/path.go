@@ -0,0 +1,27 @@
+package bootstrap
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Relative returns path relative to base, or "" if no relative path
+// exists between them (e.g. UNC paths on different shares on Windows).
+// Use RelativeErr when you need to know why.
+func Relative(base, path string) string {
+	rel, err := RelativeErr(base, path)
+	if err != nil {
+		return ""
+	}
+	return rel
+}
+
+// RelativeErr is like Relative but returns the underlying error from a
+// failed conversion instead of swallowing it.
+func RelativeErr(base, path string) (string, error) {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return "", fmt.Errorf("relative path from %q to %q: %w", base, path, err)
+	}
+	return rel, nil
+}
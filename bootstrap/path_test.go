@@ -0,0 +1,20 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRelative(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "a", "b.txt")
+	if got, want := Relative(dir, sub), filepath.Join("a", "b.txt"); got != want {
+		t.Fatalf("Relative = %q, want %q", got, want)
+	}
+}
+
+func TestRelativeErrOnIncomparablePaths(t *testing.T) {
+	if _, err := RelativeErr("relative/base", "/absolute/path"); err == nil {
+		t.Fatal("RelativeErr = nil error for incomparable paths, want an error")
+	}
+}
@@ -0,0 +1,19 @@
+package bootstrap
+
+import "os/exec"
+
+// setProcessGroup configures cmd to run in its own process group so that
+// killProcessGroup can later terminate it along with any children it
+// spawned, instead of leaving them orphaned.
+func setProcessGroup(cmd *exec.Cmd) {
+	setProcessGroupImpl(cmd)
+}
+
+// killProcessGroup terminates cmd's whole process group. It is safe to
+// call on a cmd that was never started or has already exited.
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return killProcessGroupImpl(cmd)
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package bootstrap
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroupImpl puts cmd in a new session so it becomes the leader
+// of its own process group (pgid == pid).
+func setProcessGroupImpl(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// killProcessGroupImpl sends SIGKILL to the whole process group, which
+// is addressed by the negative of its pid.
+func killProcessGroupImpl(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
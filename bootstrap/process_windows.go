@@ -0,0 +1,25 @@
+//go:build windows
+
+package bootstrap
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// setProcessGroupImpl puts cmd in a new console so taskkill's /T flag can
+// later reach its children.
+func setProcessGroupImpl(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags = syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// killProcessGroupImpl uses taskkill to terminate the whole process tree,
+// since Windows has no signal-based equivalent of killing a pgid.
+func killProcessGroupImpl(cmd *exec.Cmd) error {
+	kill := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid))
+	return kill.Run()
+}
@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// projectRootEnv overrides the project root when set, for callers where
+// this package is vendored or laid out differently than the default
+// heuristic assumes.
+const projectRootEnv = "BYTE_PROJECT_DIR"
+
+var (
+	projectDirMu       sync.Mutex
+	projectDirOverride string
+)
+
+// defaultProjectDir computes the project root by assuming this package
+// lives at <root>/bootstrap, the layout this repo has always used.
+func defaultProjectDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(filepath.Dir(file))
+}
+
+// SetProjectDir overrides the project root returned by ProjectDir and
+// CargoDir. Pass "" to clear the override and fall back to
+// BYTE_PROJECT_DIR or the default heuristic again.
+func SetProjectDir(dir string) {
+	projectDirMu.Lock()
+	defer projectDirMu.Unlock()
+	projectDirOverride = dir
+}
+
+// ProjectDir returns the root of the byte project: SetProjectDir's
+// override if set, else the BYTE_PROJECT_DIR environment variable if
+// set, else the default heuristic of two directories above this
+// package's own source file.
+func ProjectDir() string {
+	projectDirMu.Lock()
+	override := projectDirOverride
+	projectDirMu.Unlock()
+
+	if override != "" {
+		return override
+	}
+	if env := os.Getenv(projectRootEnv); env != "" {
+		return env
+	}
+	return defaultProjectDir()
+}
+
+// CargoDir returns the root of the cargo workspace that builds the byte
+// interpreter, which for this project is the project root itself.
+func CargoDir() string {
+	return ProjectDir()
+}
@@ -0,0 +1,24 @@
+package bootstrap
+
+import "testing"
+
+func TestSetProjectDirOverride(t *testing.T) {
+	defer SetProjectDir("")
+
+	SetProjectDir("/custom/root")
+	if got := ProjectDir(); got != "/custom/root" {
+		t.Fatalf("ProjectDir() = %q, want %q", got, "/custom/root")
+	}
+	if got := CargoDir(); got != "/custom/root" {
+		t.Fatalf("CargoDir() = %q, want %q", got, "/custom/root")
+	}
+}
+
+func TestProjectDirEnvOverride(t *testing.T) {
+	defer SetProjectDir("")
+	t.Setenv("BYTE_PROJECT_DIR", "/from/env")
+
+	if got := ProjectDir(); got != "/from/env" {
+		t.Fatalf("ProjectDir() = %q, want %q", got, "/from/env")
+	}
+}
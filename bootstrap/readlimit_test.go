@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadTextWithLimitRejectsOversizedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "big.out")
+	writeFile(t, path, strings.Repeat("x", 100))
+
+	if _, err := ReadTextWithLimit(path, 10); err == nil {
+		t.Fatal("ReadTextWithLimit = nil error for a file over the limit, want error")
+	}
+}
+
+func TestReadTextWithLimitAllowsFileAtOrUnderLimit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ok.out")
+	writeFile(t, path, strings.Repeat("x", 10))
+
+	got, err := ReadTextWithLimit(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != strings.Repeat("x", 10) {
+		t.Fatalf("ReadTextWithLimit = %q, want 10 x's", got)
+	}
+}
+
+func TestReadTextUsesPackageDefaultLimit(t *testing.T) {
+	defer func(orig int64) { MaxReadBytes = orig }(MaxReadBytes)
+	MaxReadBytes = 5
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "big.out")
+	writeFile(t, path, strings.Repeat("x", 100))
+
+	if _, err := ReadText(path); err == nil {
+		t.Fatal("ReadText = nil error with a small MaxReadBytes override, want error")
+	}
+}
+
+func TestReadTextMissingFileStillReturnsEmpty(t *testing.T) {
+	got, err := ReadTextWithLimit(filepath.Join(t.TempDir(), "missing.out"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "" {
+		t.Fatalf("ReadTextWithLimit = %q, want empty string for a missing file", got)
+	}
+}
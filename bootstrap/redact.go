@@ -0,0 +1,55 @@
+package bootstrap
+
+import "regexp"
+
+// Redactor replaces every match of Pattern in a test's expected and
+// actual output with Placeholder before they're compared, set per-test
+// by a `# redact: <name>[,<name>...]` directive or suite-wide via
+// TestOptions.Redactors. This lets a test whose output legitimately
+// varies between runs — a random UUID, a pointer address — still be
+// golden-tested: both sides are redacted to the same canonical form, so
+// only real differences surface.
+type Redactor struct {
+	Pattern     *regexp.Regexp
+	Placeholder string
+}
+
+// apply returns s with every match of r.Pattern replaced by
+// r.Placeholder.
+func (r Redactor) apply(s string) string {
+	return r.Pattern.ReplaceAllString(s, r.Placeholder)
+}
+
+// redactAll applies every redactor in redactors to s, in order.
+func redactAll(s string, redactors []Redactor) string {
+	for _, r := range redactors {
+		s = r.apply(s)
+	}
+	return s
+}
+
+// builtinRedactors are the named redactors available via a `# redact:`
+// directive or TestOptions.Redactors, keyed by the name used to select
+// them.
+var builtinRedactors = map[string]Redactor{
+	// ptr matches a Go pointer address as printed by %p or %v on a
+	// pointer, e.g. "0xc000010018".
+	"ptr": {
+		Pattern:     regexp.MustCompile(`0x[0-9a-fA-F]+`),
+		Placeholder: "<addr>",
+	},
+	// uuid matches a canonical hyphenated UUID in any of the common
+	// hex cases.
+	"uuid": {
+		Pattern:     regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`),
+		Placeholder: "<uuid>",
+	},
+}
+
+// RedactorByName looks up a built-in redactor by the name used in a `#
+// redact:` directive or TestOptions.Redactors, e.g. "ptr" or "uuid". It
+// reports false for an unknown name.
+func RedactorByName(name string) (Redactor, bool) {
+	r, ok := builtinRedactors[name]
+	return r, ok
+}
@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRedactorByNamePtr(t *testing.T) {
+	r, ok := RedactorByName("ptr")
+	if !ok {
+		t.Fatal("RedactorByName(\"ptr\") not found")
+	}
+	if got := r.apply("addr=0xc000010018 done"); got != "addr=<addr> done" {
+		t.Fatalf("apply = %q, want %q", got, "addr=<addr> done")
+	}
+}
+
+func TestRedactorByNameUUID(t *testing.T) {
+	r, ok := RedactorByName("uuid")
+	if !ok {
+		t.Fatal("RedactorByName(\"uuid\") not found")
+	}
+	got := r.apply("id=4f8d1c2a-9b3e-4a7d-8f1a-2c3d4e5f6a7b done")
+	if got != "id=<uuid> done" {
+		t.Fatalf("apply = %q, want %q", got, "id=<uuid> done")
+	}
+}
+
+func TestRedactorByNameUnknown(t *testing.T) {
+	if _, ok := RedactorByName("nope"); ok {
+		t.Fatal("RedactorByName(\"nope\") found, want not found")
+	}
+}
+
+func TestCheckResultRedactsPointerAddressesOnBothSides(t *testing.T) {
+	ptr, _ := RedactorByName("ptr")
+	test := &ScriptTest{Name: "t", Expected: "obj at 0xc000010018\n", Redactors: []Redactor{ptr}}
+	result := RunResult{Stdout: "obj at 0xdeadbeef\n"}
+
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("CheckResult = %v, want nil since both sides redact to the same placeholder", err)
+	}
+}
+
+func TestCheckResultRedactsUUIDsOnBothSides(t *testing.T) {
+	uuid, _ := RedactorByName("uuid")
+	test := &ScriptTest{
+		Name:      "t",
+		Expected:  "request 4f8d1c2a-9b3e-4a7d-8f1a-2c3d4e5f6a7b\n",
+		Redactors: []Redactor{uuid},
+	}
+	result := RunResult{Stdout: "request 11111111-2222-3333-4444-555555555555\n"}
+
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("CheckResult = %v, want nil since both sides redact to the same placeholder", err)
+	}
+}
+
+func TestCheckResultWithoutRedactorsStillFailsOnVolatileIDs(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "obj at 0xc000010018\n"}
+	result := RunResult{Stdout: "obj at 0xdeadbeef\n"}
+
+	if err := CheckResult(test, result); err == nil {
+		t.Fatal("CheckResult = nil, want a mismatch since nothing redacted the addresses")
+	}
+}
+
+func TestDiscoverTestsRedactDirective(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "withid.byte"), "# redact: ptr,uuid\nprint(1)")
+	writeFile(t, filepath.Join(dir, "withid.out"), "")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || len(tests[0].Redactors) != 2 {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
+
+func TestDiscoverTestsRedactDirectiveUnknownNameErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "withid.byte"), "# redact: nope\nprint(1)")
+	writeFile(t, filepath.Join(dir, "withid.out"), "")
+
+	if _, err := discoverTests(dir); err == nil {
+		t.Fatal("discoverTests = nil error, want one naming the unknown redactor")
+	}
+}
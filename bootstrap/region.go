@@ -0,0 +1,64 @@
+package bootstrap
+
+import "strings"
+
+// regionBeginMarker and regionEndMarker delimit the region of a `.out`
+// file (and the script's actual stdout) that CheckResult compares when
+// the expected file only wants to assert part of a large, volatile
+// output. A script opts in by printing these markers around the part
+// it wants checked:
+//
+//	<<<BEGIN>>>
+//	... the part worth golden-testing ...
+//	<<<END>>>
+//
+// and the `.out` file brackets the same lines the same way. Everything
+// outside the markers, on either side, is ignored. If the `.out` file
+// has markers but the actual stdout doesn't, CheckResult fails with a
+// "region markers not found" error rather than silently comparing the
+// whole output.
+const (
+	regionBeginMarker = "<<<BEGIN>>>"
+	regionEndMarker   = "<<<END>>>"
+)
+
+// extractRegion returns the text strictly between a line matching
+// regionBeginMarker and a later line matching regionEndMarker, and
+// whether both were found (in that order). Marker lines themselves are
+// excluded from the result.
+func extractRegion(text string) (string, bool) {
+	lines := strings.SplitAfter(text, "\n")
+
+	begin := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == regionBeginMarker {
+			begin = i
+			break
+		}
+	}
+	if begin == -1 {
+		return "", false
+	}
+
+	for i := begin + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == regionEndMarker {
+			return strings.Join(lines[begin+1:i], ""), true
+		}
+	}
+	return "", false
+}
+
+// regionOffset returns the number of lines preceding and including the
+// regionBeginMarker line in text, so a diff of the region extractRegion
+// returns can have its line numbers shifted back to their position in
+// the original file (see diff.UnifiedOptions.LineOffset). It returns 0
+// if text has no marker, matching extractRegion's "not found" case.
+func regionOffset(text string) int {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == regionBeginMarker {
+			return i + 1
+		}
+	}
+	return 0
+}
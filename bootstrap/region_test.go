@@ -0,0 +1,78 @@
+package bootstrap
+
+import "testing"
+
+func TestExtractRegion(t *testing.T) {
+	text := "preamble\n<<<BEGIN>>>\nkept 1\nkept 2\n<<<END>>>\ntrailer\n"
+	region, ok := extractRegion(text)
+	if !ok {
+		t.Fatal("expected markers to be found")
+	}
+	if region != "kept 1\nkept 2\n" {
+		t.Fatalf("region = %q", region)
+	}
+}
+
+func TestExtractRegionMissingMarkers(t *testing.T) {
+	if _, ok := extractRegion("no markers here\n"); ok {
+		t.Fatal("expected ok=false without markers")
+	}
+}
+
+func TestExtractRegionMissingEnd(t *testing.T) {
+	if _, ok := extractRegion("<<<BEGIN>>>\nunterminated\n"); ok {
+		t.Fatal("expected ok=false without a matching end marker")
+	}
+}
+
+func TestCheckResultComparesOnlyMarkedRegion(t *testing.T) {
+	test := &ScriptTest{
+		Name:     "t",
+		Expected: "volatile preamble: 12345\n<<<BEGIN>>>\nstable line\n<<<END>>>\n",
+	}
+	result := RunResult{Stdout: "volatile preamble: 67890\n<<<BEGIN>>>\nstable line\n<<<END>>>\n"}
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("expected only the marked region to be compared, got %v", err)
+	}
+}
+
+func TestCheckResultFailsWhenActualHasNoMarkers(t *testing.T) {
+	test := &ScriptTest{
+		Name:     "t",
+		Expected: "<<<BEGIN>>>\nstable line\n<<<END>>>\n",
+	}
+	err := CheckResult(test, RunResult{Stdout: "stable line\n"})
+	if err == nil {
+		t.Fatal("expected an error when actual output has no region markers")
+	}
+}
+
+func TestRegionOffsetCountsLinesThroughTheBeginMarker(t *testing.T) {
+	text := "preamble\n<<<BEGIN>>>\nkept\n<<<END>>>\n"
+	if got := regionOffset(text); got != 2 {
+		t.Fatalf("regionOffset = %d, want 2", got)
+	}
+}
+
+func TestRegionOffsetWithoutMarkersIsZero(t *testing.T) {
+	if got := regionOffset("no markers here\n"); got != 0 {
+		t.Fatalf("regionOffset = %d, want 0", got)
+	}
+}
+
+func TestCheckResultSetsLineOffsetFromRegionStart(t *testing.T) {
+	test := &ScriptTest{
+		Name:     "t",
+		Expected: "line 1\nline 2\n<<<BEGIN>>>\nstable\nwrong\n<<<END>>>\n",
+	}
+	result := RunResult{Stdout: "line 1\nline 2\n<<<BEGIN>>>\nstable\nactual\n<<<END>>>\n"}
+
+	err := CheckResult(test, result)
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("err = %v (%T), want *MismatchError", err, err)
+	}
+	if mismatch.LineOffset != 3 {
+		t.Fatalf("LineOffset = %d, want 3", mismatch.LineOffset)
+	}
+}
@@ -0,0 +1,95 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ax-lab/byte/diff"
+)
+
+// Reporter receives the events of a RunTests invocation as they
+// happen, so callers can plug in their own output (a dot-reporter, a
+// JSON-streaming reporter, ...) without forking the runner. TestOptions
+// defaults to textReporter, which reproduces the runner's historical
+// banner-and-PASS!-line output.
+type Reporter interface {
+	// OnStart is called just before test runs.
+	OnStart(test *ScriptTest)
+	// OnResult is called once test has finished running, or been
+	// skipped or blocked, with its final Passed/Skipped/Err fields set.
+	OnResult(test *ScriptTest)
+	// OnDetails is called for a failing test to report its error in
+	// detail (e.g. a unified diff). It is not called when Aggregate
+	// suppresses per-test detail output.
+	OnDetails(test *ScriptTest)
+}
+
+// textReporter is the default Reporter, printing the same banners,
+// "PASS!"/"SKIP:" lines, and failure details the `byte test` command
+// has always printed.
+type textReporter struct {
+	out     io.Writer
+	quiet   bool
+	binPath string
+}
+
+func (r *textReporter) OnStart(test *ScriptTest) {
+	if !r.quiet {
+		fmt.Fprintf(r.out, ">>> [TEST] %s\n", test.Name)
+	}
+}
+
+func (r *textReporter) OnResult(test *ScriptTest) {
+	switch {
+	case test.Skipped:
+		reason := test.SkipReason
+		if reason == "" {
+			reason = "no reason given"
+		}
+		if !r.quiet {
+			fmt.Fprintf(r.out, "SKIP: %s\n", reason)
+		}
+	case test.XFail && test.Passed:
+		fmt.Fprintf(r.out, "XPASS: %s unexpectedly passed (%s)\n", test.Name, xfailReasonOrDefault(test))
+	case test.XFail && !test.Passed:
+		if !r.quiet {
+			fmt.Fprintf(r.out, "XFAIL: %s\n", xfailReasonOrDefault(test))
+		}
+	case test.Passed:
+		if !r.quiet {
+			fmt.Fprintln(r.out, "PASS!")
+		}
+	default:
+		if r.quiet {
+			// The start banner was suppressed; show it now so the
+			// failure below is still attributable to a test name.
+			fmt.Fprintf(r.out, ">>> [TEST] %s\n", test.Name)
+		}
+	}
+}
+
+// xfailReasonOrDefault returns test's xfail reason, or a placeholder
+// when the `# xfail` directive didn't give one.
+func xfailReasonOrDefault(test *ScriptTest) string {
+	if test.XFailReason == "" {
+		return "no reason given"
+	}
+	return test.XFailReason
+}
+
+func (r *textReporter) OnDetails(test *ScriptTest) {
+	if r.binPath != "" {
+		fmt.Fprintf(r.out, "repro: %s\n", reproCommand(r.binPath, test))
+	}
+	switch mismatch := test.Err.(type) {
+	case *MismatchError:
+		fmt.Fprintf(r.out, "FAIL: %s: stdout mismatch\n", mismatch.Test)
+		mismatch.WriteUnified(r.out, diff.ShouldColor())
+	case *JSONMismatchError:
+		fmt.Fprintf(r.out, "FAIL: %v\n", mismatch)
+		fmt.Fprintln(r.out, "--- line diff ---")
+		_ = diff.WriteUnified(r.out, mismatch.Blocks, 3, diff.ShouldColor())
+	default:
+		fmt.Fprintf(r.out, "FAIL: %v\n", test.Err)
+	}
+}
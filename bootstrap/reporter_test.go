@@ -0,0 +1,33 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type countingReporter struct {
+	starts, results, details int
+}
+
+func (r *countingReporter) OnStart(test *ScriptTest)   { r.starts++ }
+func (r *countingReporter) OnResult(test *ScriptTest)  { r.results++ }
+func (r *countingReporter) OnDetails(test *ScriptTest) { r.details++ }
+
+func TestRunTestsUsesCustomReporter(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "pass.byte", "")
+	if err := os.WriteFile(filepath.Join(dir, "pass.out"), []byte(""), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reporter := &countingReporter{}
+	summary := RunTests(TestOptions{Dir: dir, BinPath: "true", Reporter: reporter})
+
+	if summary.Total != 1 {
+		t.Fatalf("Total = %d, want 1", summary.Total)
+	}
+	if reporter.starts != 1 || reporter.results != 1 {
+		t.Fatalf("reporter calls = %+v, want 1 start and 1 result", reporter)
+	}
+}
@@ -0,0 +1,34 @@
+package bootstrap
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// shellQuote quotes s for safe use as a single POSIX shell word,
+// wrapping it in single quotes and escaping any single quote it
+// contains. It always quotes, even when s needs no escaping, so the
+// printed command is unambiguous to read.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// reproCommand assembles the shell command line that reproduces test's
+// run: a `cd` into its directory (relative to ProjectDir for
+// readability) followed by the interpreter invocation, with any
+// per-test environment variables set inline.
+func reproCommand(binPath string, test *ScriptTest) string {
+	dir := Relative(ProjectDir(), test.Dir)
+	if dir == "" {
+		dir = test.Dir
+	}
+
+	var cmd strings.Builder
+	fmt.Fprintf(&cmd, "cd %s && ", shellQuote(dir))
+	for _, kv := range test.Env {
+		fmt.Fprintf(&cmd, "%s ", kv)
+	}
+	fmt.Fprintf(&cmd, "%s %s", shellQuote(binPath), shellQuote(filepath.Base(test.Source)))
+	return cmd.String()
+}
@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShellQuoteEscapesSingleQuotes(t *testing.T) {
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Fatalf("shellQuote = %q, want %q", got, `'it'\''s'`)
+	}
+}
+
+func TestReproCommandIncludesDirAndEnv(t *testing.T) {
+	defer SetProjectDir("")
+	SetProjectDir("/project")
+
+	test := &ScriptTest{
+		Dir:    "/project/tests/foo",
+		Source: "/project/tests/foo/bar.byte",
+		Env:    []string{"FOO=bar"},
+	}
+	got := reproCommand("/path/to/byte", test)
+
+	if !strings.Contains(got, "cd 'tests/foo'") {
+		t.Fatalf("reproCommand = %q, missing relative cd", got)
+	}
+	if !strings.Contains(got, "FOO=bar") {
+		t.Fatalf("reproCommand = %q, missing env var", got)
+	}
+	if !strings.Contains(got, "'/path/to/byte' 'bar.byte'") {
+		t.Fatalf("reproCommand = %q, missing interpreter invocation", got)
+	}
+}
+
+func TestTextReporterPrintsReproCommandOnDetails(t *testing.T) {
+	var buf strings.Builder
+	r := &textReporter{out: &buf, binPath: "/path/to/byte"}
+	test := &ScriptTest{Name: "t", Dir: "/tmp/t", Source: "/tmp/t/t.byte", Err: &MismatchError{Test: "t", Expected: "a", Actual: "b"}}
+
+	r.OnDetails(test)
+	if !strings.Contains(buf.String(), "repro:") {
+		t.Fatalf("OnDetails output missing repro line:\n%s", buf.String())
+	}
+}
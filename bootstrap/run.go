@@ -0,0 +1,357 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// TestOptions configures a RunTests invocation.
+type TestOptions struct {
+	// Dir is the root directory to search for `.byte` test scripts.
+	Dir string
+	// BinPath is the path to the byte interpreter binary used to run
+	// each script.
+	BinPath string
+	// Quiet suppresses the per-test start banner and "PASS!" line for
+	// tests that pass, printing only failures and the final summary.
+	// The default (false) prints every test, matching historical
+	// behavior.
+	Quiet bool
+	// Strict fails the run (via TestSummary.StrictViolation) when zero
+	// tests were executed, when any test was skipped, when a `# only`
+	// directive deselected tests, or when an `# xfail` test
+	// unexpectedly passed. The default
+	// (false) treats an all-skipped or empty suite as a pass, which
+	// can silently hide a broken suite in CI.
+	Strict bool
+	// Output is where the per-test banners and summary lines are
+	// written. Defaults to os.Stdout when nil, so embedders and tests
+	// of the runner itself can capture or redirect it.
+	Output io.Writer
+
+	// Aggregate replaces each failing test's full diff output with a
+	// single end-of-run report grouping failures by the *kind* of
+	// difference (see AggregateSignature), for runs where many similar
+	// failures would otherwise bury the interesting ones. The default
+	// (false) prints every failure's details as it happens.
+	Aggregate bool
+
+	// ArtifactsDir, if non-empty, saves every executed test's captured
+	// stdout, stderr, and exit code under
+	// <ArtifactsDir>/<relative test dir>/<test name>/, for post-mortem
+	// debugging. Skipped and blocked tests, which never ran, have no
+	// artifacts written. Each run overwrites the previous one's files
+	// for the same test.
+	ArtifactsDir string
+
+	// Reporter receives OnStart/OnResult/OnDetails as each test runs,
+	// in place of the default banner-and-PASS!-line text output.
+	// Defaults to a textReporter over Output when nil, which reproduces
+	// the runner's historical output exactly.
+	Reporter Reporter
+
+	// StripANSI, if true, strips terminal escape sequences from every
+	// test's stdout before comparison, as if every script carried a `#
+	// strip-ansi` directive. A script's own directive still applies
+	// when this is false.
+	StripANSI bool
+
+	// Redactors, if non-empty, are applied to every test in addition to
+	// any it declares via its own `# redact:` directive, for a
+	// redactor the whole suite needs (e.g. every test's output embeds a
+	// pointer address) without editing every script.
+	Redactors []Redactor
+
+	// EnvAllowlist, if non-nil, runs every script with a minimal
+	// environment built from only these variable names (plus Env
+	// additions), instead of inheriting the full parent environment —
+	// see RunOptions.EnvAllowlist. It sits below the existing env
+	// layering: a test's Env (directory .env, then its own `# env:`
+	// directive) is always appended on top, so allowlisting what's
+	// inherited never hides a value a test explicitly set itself. The
+	// default (nil) inherits everything, matching historical behavior.
+	EnvAllowlist []string
+
+	// Bail, if positive, stops RunTests from launching any further test
+	// once this many have failed (an XFail failure doesn't count,
+	// matching how it's excluded from TestSummary.Failed), leaving the
+	// rest unrun instead of reporting them. The default (0) never
+	// bails, running every discovered test regardless of how many
+	// failed. RunTests executes tests sequentially, so this takes
+	// effect as soon as the Nth failure is observed.
+	Bail int
+
+	// NoSideEffects, if true, snapshots each test's directory before
+	// running it and fails the test if any file exists afterward that
+	// wasn't there before and isn't named by the test's Creates
+	// allow-list (see checkNoSideEffects), catching a script that
+	// writes stray files it never declared. The default (false) never
+	// checks this, matching historical behavior.
+	NoSideEffects bool
+
+	// Shard, if its Count is non-zero, restricts the run to one
+	// disjoint slice of the discovered test set (see ShardTests), for
+	// splitting a suite across several CI machines. The zero value
+	// (Count 0) runs everything, matching historical behavior.
+	Shard ShardSpec
+
+	// Context, if non-nil, governs the run: canceling it stops RunTests
+	// from launching any further test and cancels whichever test is
+	// currently executing (via RunContext), the same way a Ctrl-C
+	// handler would. Defaults to context.Background() (never canceled)
+	// when nil.
+	Context context.Context
+}
+
+// TestSummary is the aggregate outcome of a RunTests invocation.
+type TestSummary struct {
+	Total, Passed, Failed, Skipped int
+	Results                        []ScriptTest
+
+	// XFailed counts tests that declared `# xfail` and failed as
+	// expected; they are not included in Failed. XPassed counts `#
+	// xfail` tests that unexpectedly passed; they are included in
+	// Passed.
+	XFailed, XPassed int
+
+	// StrictViolation is set when TestOptions.Strict is true and the
+	// run had zero executed tests, at least one skipped test, FocusMode
+	// set, or at least one XPASS, even though nothing outright failed.
+	StrictViolation bool
+
+	// FocusMode is set when at least one discovered test carried a
+	// `# only` directive, so some tests were deselected.
+	FocusMode bool
+
+	// Shard echoes TestOptions.Shard, so callers that print or log the
+	// summary can note which shard ran.
+	Shard ShardSpec
+
+	// Interrupted is set when TestOptions.Context was canceled before
+	// every discovered test finished running. Results reflects only
+	// what ran before the cancellation.
+	Interrupted bool
+
+	// Bailed is set when TestOptions.Bail was positive and the run
+	// stopped early after reaching that many failures. Results reflects
+	// only what ran before bailing.
+	Bailed bool
+}
+
+// focusOnly implements `# only` focus mode: if any test is marked Only,
+// every other test is deselected from the run. It returns the tests to
+// run and how many were deselected.
+func focusOnly(tests []*ScriptTest) ([]*ScriptTest, int) {
+	var focused []*ScriptTest
+	for _, test := range tests {
+		if test.Only {
+			focused = append(focused, test)
+		}
+	}
+	if focused == nil {
+		return tests, 0
+	}
+	return focused, len(tests) - len(focused)
+}
+
+// RunTests discovers, runs, and checks every script test under
+// opts.Dir, printing the same per-test banners and summary that the
+// `byte test` command has always printed (to opts.Output, or os.Stdout
+// if unset), and returns the results as a TestSummary so callers can
+// inspect them without parsing that output.
+func RunTests(opts TestOptions) TestSummary {
+	out := opts.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	reporter := opts.Reporter
+	if reporter == nil {
+		reporter = &textReporter{out: out, quiet: opts.Quiet, binPath: opts.BinPath}
+	}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var summary TestSummary
+
+	tests, err := discoverTests(opts.Dir)
+	if err != nil {
+		fmt.Fprintf(out, "error: could not discover tests: %v\n", err)
+		return summary
+	}
+
+	if opts.StripANSI {
+		for _, test := range tests {
+			test.StripANSI = true
+		}
+	}
+
+	if len(opts.Redactors) > 0 {
+		for _, test := range tests {
+			test.Redactors = append(append([]Redactor{}, opts.Redactors...), test.Redactors...)
+		}
+	}
+
+	if opts.Shard.Count > 0 {
+		summary.Shard = opts.Shard
+		tests = ShardTests(tests, opts.Shard)
+		fmt.Fprintf(out, "shard %d/%d: %d test(s) selected\n", opts.Shard.Index, opts.Shard.Count, len(tests))
+	}
+
+	tests, deselected := focusOnly(tests)
+	if deselected > 0 {
+		summary.FocusMode = true
+		fmt.Fprintf(out, "focus mode: %d test(s) deselected by `# only`\n", deselected)
+	}
+
+	tests, err = orderByDependencies(tests)
+	if err != nil {
+		fmt.Fprintf(out, "error: %v\n", err)
+		return summary
+	}
+
+	passed := make(map[string]bool, len(tests))
+	for _, test := range tests {
+		if ctx.Err() != nil {
+			summary.Interrupted = true
+			break
+		}
+
+		reporter.OnStart(test)
+
+		if test.Skipped {
+			summary.Total++
+			summary.Skipped++
+			reporter.OnResult(test)
+			summary.Results = append(summary.Results, *test)
+			continue
+		}
+
+		if blockedBy := firstFailedPrereq(test.After, passed); blockedBy != "" {
+			test.Skipped = true
+			test.SkipReason = fmt.Sprintf("blocked: prerequisite %q did not pass", blockedBy)
+			summary.Total++
+			summary.Skipped++
+			reporter.OnResult(test)
+			summary.Results = append(summary.Results, *test)
+			continue
+		}
+
+		if test.Creates != nil {
+			if err := cleanupCreatedFiles(test); err != nil {
+				test.Err = err
+			}
+		}
+
+		var result RunResult
+		var runErr error
+		ran := false
+		if test.Err == nil {
+			var before map[string]bool
+			if opts.NoSideEffects {
+				before, err = snapshotDir(test.Dir)
+				if err != nil {
+					test.Err = fmt.Errorf("%s: snapshotting directory before run: %w", test.Name, err)
+				}
+			}
+
+			if test.Err == nil {
+				ran = true
+				result, runErr = runScript(ctx, opts.BinPath, test, opts.EnvAllowlist)
+				if runErr != nil {
+					if ctx.Err() != nil {
+						test.Err = fmt.Errorf("interrupted")
+					} else {
+						test.Err = fmt.Errorf("running script: %w", runErr)
+					}
+				} else {
+					test.ExitCode = result.ExitCode
+					test.Err = CheckResult(test, result)
+					if test.Err == nil && test.Creates != nil {
+						test.Err = checkCreatedFiles(test)
+					}
+					if test.Err == nil && opts.NoSideEffects {
+						test.Err = checkNoSideEffects(test, before)
+					}
+				}
+			}
+		}
+
+		if ran && runErr == nil && opts.ArtifactsDir != "" {
+			if err := writeArtifacts(opts.ArtifactsDir, opts.Dir, test, result); err != nil {
+				fmt.Fprintf(out, "warning: could not write artifacts for %s: %v\n", test.Name, err)
+			}
+		}
+		test.Passed = test.Err == nil
+		passed[test.Name] = test.Passed
+
+		summary.Total++
+		switch {
+		case test.XFail && test.Passed:
+			summary.XPassed++
+			summary.Passed++
+		case test.XFail && !test.Passed:
+			summary.XFailed++
+		case test.Passed:
+			summary.Passed++
+		default:
+			summary.Failed++
+		}
+		reporter.OnResult(test)
+		if !test.Passed && !test.XFail && !opts.Aggregate {
+			reporter.OnDetails(test)
+		}
+		summary.Results = append(summary.Results, *test)
+
+		if opts.Bail > 0 && summary.Failed >= opts.Bail {
+			summary.Bailed = true
+			break
+		}
+	}
+
+	if opts.Aggregate && summary.Failed > 0 {
+		fmt.Fprintln(out, "--- aggregate failure report ---")
+		WriteAggregate(out, summary.Results)
+	}
+
+	if summary.Interrupted {
+		fmt.Fprintln(out, "interrupted: showing partial results")
+	}
+	if summary.Bailed {
+		fmt.Fprintf(out, "bailed after %d failure(s): %d test(s) not run\n", opts.Bail, len(tests)-len(summary.Results))
+	}
+
+	if summary.Total == 0 {
+		fmt.Fprintln(out, "0 tests executed")
+	}
+	if opts.Strict && (summary.Total == 0 || summary.Skipped > 0 || summary.FocusMode || summary.XPassed > 0) {
+		summary.StrictViolation = true
+	}
+
+	return summary
+}
+
+// OutputDetails prints a failing test's error for diagnosis to out,
+// using the same format as the default Reporter. It is always shown
+// for failures, regardless of TestOptions.Quiet.
+func OutputDetails(out io.Writer, test *ScriptTest) {
+	(&textReporter{out: out}).OnDetails(test)
+}
+
+// runScript executes the byte interpreter at binPath against the test's
+// source file and captures its output, killing the subprocess if ctx
+// is canceled before it exits (e.g. by a Ctrl-C handler). envAllowlist
+// is TestOptions.EnvAllowlist, threaded straight through to
+// RunOptions.EnvAllowlist; test.Env is layered on top either way.
+func runScript(ctx context.Context, binPath string, test *ScriptTest, envAllowlist []string) (RunResult, error) {
+	dir := test.Dir
+	if test.WorkDir != "" {
+		dir = test.WorkDir
+	}
+	return RunContext(ctx, binPath, []string{test.Source}, RunOptions{Dir: dir, Env: test.Env, EnvAllowlist: envAllowlist})
+}
@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+)
+
+// Runner locates the byte interpreter binary and tracks its staleness
+// relative to the cargo workspace that produces it, so callers can
+// decide whether a rebuild is needed before running tests.
+type Runner struct {
+	binPath  string
+	cargoDir string
+
+	// Build overrides the command EnsureBuilt uses to build the
+	// binary. The zero value runs DefaultBuildConfig's `cargo build`.
+	Build BuildConfig
+}
+
+// NewRunner returns a Runner for the interpreter binary at binPath,
+// built from the cargo workspace rooted at cargoDir.
+func NewRunner(binPath, cargoDir string) *Runner {
+	return &Runner{binPath: binPath, cargoDir: cargoDir}
+}
+
+// CargoDir returns the root of the cargo workspace this Runner's binary
+// is built from.
+func (r *Runner) CargoDir() string {
+	return r.cargoDir
+}
+
+// IsStale reports whether the binary is missing, or older than the
+// newest `.rs`/`.toml` file under CargoDir, meaning it needs a rebuild.
+func (r *Runner) IsStale() (bool, error) {
+	stale, _, err := NeedsRebuild(r.cargoDir, r.binPath)
+	return stale, err
+}
+
+// EnsureBuilt builds the interpreter binary if it doesn't exist yet,
+// using r.Build (or `cargo build` by default). Unlike IsStale/Boot, it
+// never rebuilds an existing binary just because a source file changed
+// more recently — only a missing binary triggers a build — so CI that
+// already builds the workspace as a separate step can call this once
+// up front without risking a surprise double build.
+func (r *Runner) EnsureBuilt() error {
+	if _, err := os.Stat(r.binPath); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return cargoBuild(r.cargoDir, r.Build.withDefaults())
+}
+
+// NeedsRebuild reports whether the binary at exePath is missing, or
+// older than the newest `.rs`/`.toml` file under cargoDir, without
+// rebuilding or running anything. When stale is true, reason names what
+// made it so (either that the binary doesn't exist, or the source file
+// that is newer than it), for tools like `byte --check-stale` that want
+// to explain a staleness verdict. Runner.IsStale and Boot are thin
+// wrappers over this.
+func NeedsRebuild(cargoDir, exePath string) (stale bool, reason string, err error) {
+	binInfo, err := os.Stat(exePath)
+	if os.IsNotExist(err) {
+		return true, fmt.Sprintf("%s does not exist", exePath), nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	newestPath, newestTime, err := newestSourceFile(cargoDir)
+	if err != nil {
+		return false, "", err
+	}
+	if newestTime.After(binInfo.ModTime()) {
+		return true, fmt.Sprintf("%s is newer than %s", newestPath, exePath), nil
+	}
+	return false, "", nil
+}
@@ -0,0 +1,158 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunnerIsStaleMissingBinary(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.rs"), "fn main() {}")
+
+	r := NewRunner(filepath.Join(dir, "byte"), dir)
+	stale, err := r.IsStale()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stale {
+		t.Fatal("IsStale = false for a missing binary, want true")
+	}
+}
+
+func TestRunnerIsStaleComparesMtimes(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "byte")
+	writeFile(t, binPath, "stale binary")
+
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(binPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	srcPath := filepath.Join(dir, "main.rs")
+	writeFile(t, srcPath, "fn main() {}")
+
+	r := NewRunner(binPath, dir)
+	stale, err := r.IsStale()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stale {
+		t.Fatal("IsStale = false for a binary older than its sources, want true")
+	}
+
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(binPath, future, future); err != nil {
+		t.Fatal(err)
+	}
+	stale, err = r.IsStale()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale {
+		t.Fatal("IsStale = true for a binary newer than its sources, want false")
+	}
+}
+
+func TestNeedsRebuildMissingBinary(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.rs"), "fn main() {}")
+
+	stale, reason, err := NeedsRebuild(dir, filepath.Join(dir, "byte"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stale {
+		t.Fatal("NeedsRebuild = false for a missing binary, want true")
+	}
+	if !strings.Contains(reason, "does not exist") {
+		t.Fatalf("reason = %q, want it to mention the missing binary", reason)
+	}
+}
+
+func TestNeedsRebuildNewerSource(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "byte")
+	writeFile(t, binPath, "stale binary")
+
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(binPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	srcPath := filepath.Join(dir, "main.rs")
+	writeFile(t, srcPath, "fn main() {}")
+
+	stale, reason, err := NeedsRebuild(dir, binPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stale {
+		t.Fatal("NeedsRebuild = false for a binary older than its sources, want true")
+	}
+	if !strings.Contains(reason, srcPath) {
+		t.Fatalf("reason = %q, want it to name %q", reason, srcPath)
+	}
+}
+
+func TestNeedsRebuildUpToDate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.rs"), "fn main() {}")
+
+	binPath := filepath.Join(dir, "byte")
+	writeFile(t, binPath, "fresh binary")
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(binPath, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	stale, reason, err := NeedsRebuild(dir, binPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale {
+		t.Fatalf("NeedsRebuild = true for an up-to-date binary, want false (reason %q)", reason)
+	}
+}
+
+func TestRunnerEnsureBuiltSkipsExistingBinary(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "byte")
+	writeFile(t, binPath, "already built")
+
+	r := NewRunner(binPath, dir)
+	r.Build = BuildConfig{Command: "definitely-not-a-real-command-xyz"}
+	if err := r.EnsureBuilt(); err != nil {
+		t.Fatalf("EnsureBuilt() = %v, want nil for an already-existing binary (build command should never run)", err)
+	}
+}
+
+func TestRunnerEnsureBuiltRunsBuildWhenMissing(t *testing.T) {
+	dir := t.TempDir()
+	r := NewRunner(filepath.Join(dir, "byte"), dir)
+	r.Build = BuildConfig{Command: "true"}
+
+	if err := r.EnsureBuilt(); err != nil {
+		t.Fatalf("EnsureBuilt() = %v, want nil", err)
+	}
+}
+
+func TestRunnerEnsureBuiltPropagatesBuildError(t *testing.T) {
+	dir := t.TempDir()
+	r := NewRunner(filepath.Join(dir, "byte"), dir)
+	r.Build = BuildConfig{Command: "definitely-not-a-real-command-xyz"}
+
+	if err := r.EnsureBuilt(); err == nil {
+		t.Fatal("EnsureBuilt() = nil, want an error for a nonexistent build command")
+	}
+}
+
+func TestRunnerCargoDir(t *testing.T) {
+	r := NewRunner("bin/byte", "cargo/root")
+	if r.CargoDir() != "cargo/root" {
+		t.Fatalf("CargoDir() = %q, want %q", r.CargoDir(), "cargo/root")
+	}
+}
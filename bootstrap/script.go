@@ -0,0 +1,397 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/ax-lab/byte/diff"
+)
+
+// StderrPolicy controls how a ScriptTest's captured stderr affects its
+// pass/fail outcome.
+type StderrPolicy int
+
+const (
+	// StderrStrict fails the test if any stderr output was produced.
+	// This is the default, matching the historical behavior.
+	StderrStrict StderrPolicy = iota
+	// StderrIgnore never fails the test based on stderr output.
+	StderrIgnore
+	// StderrMatch compares stderr against ExpectedStderrFile instead of
+	// treating any output as a failure.
+	StderrMatch
+)
+
+// ScriptTest describes a single script-based test case: a byte source
+// file to run and the expected outcome.
+type ScriptTest struct {
+	Name string
+	Dir  string
+	// Source is the path to the .byte script being tested.
+	Source string
+
+	// WorkDir, if non-empty, is the directory the script is run in,
+	// overriding Dir. Set by discovery from a `<name>.meta.json`
+	// sidecar's `workdir` field (relative to Dir), for a test whose
+	// expected output lives beside its script but that needs to run
+	// from elsewhere, e.g. a shared fixtures root. Discovery itself
+	// (finding the script, its expected output, its other sidecars)
+	// always uses Dir; only the run itself honors WorkDir.
+	WorkDir string
+
+	// Expected is the expected stdout contents.
+	Expected string
+
+	// StderrPolicy controls how stderr output affects the result.
+	// The zero value is StderrStrict.
+	StderrPolicy StderrPolicy
+	// ExpectedStderrFile holds the path compared against stderr when
+	// StderrPolicy is StderrMatch.
+	ExpectedStderrFile string
+
+	// Window, if non-nil, restricts the stdout comparison to a subset
+	// of lines instead of the whole output.
+	Window *LineWindow
+
+	// Skipped and SkipReason are set by discovery when a script
+	// declares itself skipped via a `# skip` directive or `.skip`
+	// sidecar file.
+	Skipped    bool
+	SkipReason string
+
+	// Only is set by discovery when the script carries a `# only`
+	// directive, putting the whole run into focus mode.
+	Only bool
+
+	// XFail and XFailReason are set by discovery when a script declares
+	// itself "expected to fail" via an `# xfail: reason` directive: a
+	// documented known-broken test that shouldn't fail the suite. A
+	// failing XFail test is reported as XFAIL instead of FAIL; one that
+	// unexpectedly passes is reported as XPASS, which -strict treats as
+	// a violation so stale xfails get noticed and cleaned up.
+	XFail       bool
+	XFailReason string
+
+	// Env lists the "KEY=VALUE" environment variables the script runs
+	// with, beyond the inherited environment: first its directory's
+	// .env file, then its own `# env:` directive lines, which take
+	// precedence over same-named .env keys.
+	Env []string
+
+	// After lists the names of tests that must pass before this one
+	// runs, set by discovery from a `# after: name1, name2` directive.
+	// A prerequisite that fails or is itself blocked causes this test
+	// to be skipped ("blocked") rather than run.
+	After []string
+
+	// JSONExpected, when non-nil, holds a JSON-array expectation loaded
+	// from a `.out.json` sidecar: stdout is compared line-by-line
+	// against each element instead of against Expected verbatim.
+	JSONExpected []interface{}
+	// JSONCompare controls the numeric/string leniency of that
+	// comparison.
+	JSONCompare JSONCompareOptions
+
+	// ExpectCmd, if non-empty, is a shell command declared by the script's
+	// `# expect-cmd:` directive; its stdout, captured at discovery time,
+	// becomes Expected instead of reading a `.out` file. This enables
+	// differential testing against a reference implementation.
+	ExpectCmd string
+
+	// Creates maps the paths of files (relative to Dir) the script is
+	// expected to create, loaded from a `<name>.creates.json` sidecar,
+	// to their expected contents. Nil means the test makes no such
+	// assertion.
+	Creates map[string]string
+
+	// StripANSI strips terminal escape sequences (color, cursor
+	// movement) from stdout before comparing it against Expected, set
+	// by discovery from a `# strip-ansi` directive or TestOptions'
+	// global default. It has no effect when JSONExpected is set.
+	StripANSI bool
+
+	// Redactors replace volatile substrings (pointer addresses, UUIDs)
+	// in both Expected and stdout with a canonical placeholder before
+	// they're compared, set by discovery from a `# redact: <name>` (or
+	// `# redact: <name>,<name>`) directive or TestOptions' suite-wide
+	// default. It has no effect when JSONExpected is set.
+	Redactors []Redactor
+
+	// StripPrefix, if non-nil, is stripped from the start of every line
+	// of both stdout and Expected before they're compared, set by
+	// discovery from a `# strip-prefix: <regex>` directive. This lets a
+	// test ignore a variable leading prefix (a log timestamp, a PID)
+	// while still diffing the rest of the line. It has no effect when
+	// JSONExpected is set.
+	StripPrefix *regexp.Regexp
+
+	// CompareMode controls how Expected is compared against stdout,
+	// set by discovery from a `# compare: <mode>` directive. The
+	// default (CompareExact) requires a verbatim match. It has no
+	// effect when JSONExpected is set.
+	CompareMode CompareMode
+	// CompareTolerance is the epsilon used when CompareMode is
+	// CompareNumericTolerance.
+	CompareTolerance float64
+
+	// Comparator, if non-empty, names a Comparator registered via
+	// RegisterComparator (or a built-in one), set by discovery from a
+	// `# comparator: <name>` directive. When set, CheckResult dispatches
+	// to it instead of the built-in Expected/CompareMode logic, so
+	// StripANSI, StripPrefix, Redactors, Window, and CompareMode have no
+	// effect. A non-nil JSONExpected is checked first and takes
+	// precedence, in which case the comparator is not consulted.
+	Comparator string
+
+	// Passed and Err record the outcome after RunTests executes the
+	// test; they are zero-valued until then.
+	Passed bool
+	Err    error
+	// ExitCode is the interpreter's exit code from running Source, or 0
+	// if the test never ran (skipped or blocked).
+	ExitCode int
+}
+
+// LineWindow selects a 1-indexed, inclusive range of lines to compare,
+// useful when a test only cares about part of a program's output. End
+// of 0 means "through the last line".
+type LineWindow struct {
+	Start, End int
+}
+
+// apply returns the lines of s selected by w.
+func (w *LineWindow) apply(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	start := w.Start - 1
+	if start < 0 {
+		start = 0
+	}
+	end := w.End
+	if end <= 0 || end > len(lines) {
+		end = len(lines)
+	}
+	if start > end {
+		start = end
+	}
+	return strings.Join(lines[start:end], "")
+}
+
+// RunResult is the captured outcome of executing a ScriptTest's program.
+type RunResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// MismatchError reports a stdout mismatch along with the line-level diff
+// blocks between expected and actual output, so callers can render it as
+// text or serialize it with Blocks.
+type MismatchError struct {
+	Test     string
+	Expected string
+	Actual   string
+	Blocks   []diff.DiffBlock
+
+	// Mode and Tolerance record the comparison that failed, for
+	// failure output. Mode is CompareExact (its zero value) unless the
+	// test declared a `# compare:` directive.
+	Mode      CompareMode
+	Tolerance float64
+
+	// RawRunActual holds the program's stdout before ANSI stripping
+	// and/or prefix stripping, set only when the test used
+	// `# strip-ansi` or `# strip-prefix`. Actual and Blocks always
+	// reflect the stripped content that was actually compared; this
+	// field lets failure output also show what the program really
+	// printed.
+	RawRunActual string
+	// RawExpected holds Expected before prefix stripping, set only
+	// when the test used `# strip-prefix`, so failure output can show
+	// both the raw expected line (with its timestamp or other variable
+	// prefix) and the stripped form that was actually compared.
+	RawExpected string
+
+	// LineOffset is the number of lines that preceded the compared
+	// region in the original output, set when the test used the
+	// marker-region feature, so WriteUnified reports line numbers
+	// relative to the real file rather than the extracted slice. It's
+	// 0 when the whole output was compared.
+	LineOffset int
+
+	// TrailingNewlineOnly is set when Expected and Actual are otherwise
+	// identical and differ only in a trailing newline, the case behind
+	// many confusing one-line diffs. Error() reports this explicitly
+	// instead of rendering the near-empty diff.
+	TrailingNewlineOnly bool
+}
+
+// trailingNewlineOnlyDiff reports whether expected and actual differ
+// but become equal once a single trailing newline is stripped from
+// each, the common case of a script being off by one blank line.
+func trailingNewlineOnlyDiff(expected, actual string) bool {
+	return expected != actual &&
+		strings.TrimSuffix(expected, "\n") == strings.TrimSuffix(actual, "\n")
+}
+
+func (e *MismatchError) Error() string {
+	header := fmt.Sprintf("%s: stdout mismatch", e.Test)
+	switch e.Mode {
+	case CompareNumericTolerance:
+		header += fmt.Sprintf(" (numeric-tolerance: %g)", e.Tolerance)
+	case CompareRegex:
+		header += " (regex)"
+	}
+	if e.TrailingNewlineOnly {
+		if strings.HasSuffix(e.Expected, "\n") {
+			return fmt.Sprintf("%s: actual is missing a trailing newline that expected has", header)
+		}
+		return fmt.Sprintf("%s: actual has an extra trailing newline that expected doesn't", header)
+	}
+	out := fmt.Sprintf("%s\n--- expected ---\n%s\n--- actual ---\n%s", header, e.Expected, e.Actual)
+	if e.RawExpected != "" {
+		out += fmt.Sprintf("\n--- expected (raw, before strip-prefix) ---\n%s", e.RawExpected)
+	}
+	if e.RawRunActual != "" {
+		out += fmt.Sprintf("\n--- actual (raw, before stripping) ---\n%s", e.RawRunActual)
+	}
+	return out
+}
+
+// JSON renders the mismatch's diff blocks as machine-readable JSON, for
+// tooling that wants to render or post-process a failure without
+// re-diffing the raw text.
+func (e *MismatchError) JSON() ([]byte, error) {
+	return json.Marshal(e.Blocks)
+}
+
+// WriteUnified streams a unified diff of the mismatch to w, coloring
+// added/removed lines when color is true. Unlike Error, this scales to
+// huge outputs since it never builds the whole diff as one string.
+func (e *MismatchError) WriteUnified(w io.Writer, color bool) error {
+	return diff.WriteUnifiedWithOptions(w, e.Blocks, 3, diff.UnifiedOptions{Color: color, LineOffset: e.LineOffset})
+}
+
+// CheckResult compares result against test's expectations and returns a
+// non-nil error describing the first mismatch found, or nil if the test
+// passed.
+func CheckResult(test *ScriptTest, result RunResult) error {
+	if test.JSONExpected != nil {
+		if err := checkJSONExpected(test, result.Stdout); err != nil {
+			return err
+		}
+		return checkStderr(test, result)
+	}
+
+	if test.Comparator != "" {
+		cmp, ok := ComparatorByName(test.Comparator)
+		if !ok {
+			return fmt.Errorf("%s: unknown comparator %q", test.Name, test.Comparator)
+		}
+		if ok, detail := cmp.Compare(test.Expected, result.Stdout); !ok {
+			return &ComparatorMismatchError{Test: test.Name, Comparator: test.Comparator, Detail: detail}
+		}
+		return checkStderr(test, result)
+	}
+
+	expected, actual := test.Expected, result.Stdout
+	if len(test.Redactors) > 0 {
+		expected = redactAll(expected, test.Redactors)
+		actual = redactAll(actual, test.Redactors)
+	}
+	var rawActual, rawExpected string
+	if test.StripANSI {
+		rawActual = actual
+		actual = diff.StripANSI(actual)
+	}
+	if test.StripPrefix != nil {
+		if rawActual == "" {
+			rawActual = actual
+		}
+		rawExpected = expected
+		expected = stripLinePrefix(expected, test.StripPrefix)
+		actual = stripLinePrefix(actual, test.StripPrefix)
+	}
+	var lineOffset int
+	if region, ok := extractRegion(expected); ok {
+		lineOffset = regionOffset(expected)
+		expected = region
+		actualRegion, ok := extractRegion(actual)
+		if !ok {
+			return fmt.Errorf("%s: region markers (%s/%s) not found in actual output", test.Name, regionBeginMarker, regionEndMarker)
+		}
+		actual = actualRegion
+	}
+	if test.Window != nil {
+		expected = test.Window.apply(expected)
+		actual = test.Window.apply(actual)
+	}
+
+	matched := actual == expected
+	if !matched && test.CompareMode != CompareExact {
+		var err error
+		matched, err = compareModeLines(test.CompareMode, test.CompareTolerance, expected, actual)
+		if err != nil {
+			return fmt.Errorf("%s: %w", test.Name, err)
+		}
+	}
+
+	if !matched {
+		return &MismatchError{
+			Test:                test.Name,
+			Expected:            expected,
+			Actual:              actual,
+			Blocks:              diff.Compare(strings.SplitAfter(expected, "\n"), strings.SplitAfter(actual, "\n")),
+			Mode:                test.CompareMode,
+			Tolerance:           test.CompareTolerance,
+			RawRunActual:        rawActual,
+			RawExpected:         rawExpected,
+			LineOffset:          lineOffset,
+			TrailingNewlineOnly: test.CompareMode == CompareExact && trailingNewlineOnlyDiff(expected, actual),
+		}
+	}
+
+	return checkStderr(test, result)
+}
+
+// stripLinePrefix removes the leading match of re from each line of s,
+// leaving lines that don't match unchanged.
+func stripLinePrefix(s string, re *regexp.Regexp) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSuffix(line, "\n")
+		if loc := re.FindStringIndex(trimmed); loc != nil {
+			lines[i] = trimmed[loc[1]:] + line[len(trimmed):]
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// checkStderr applies test's StderrPolicy to result's captured stderr.
+func checkStderr(test *ScriptTest, result RunResult) error {
+	if result.Stderr == "" && test.StderrPolicy != StderrMatch {
+		return nil
+	}
+
+	switch test.StderrPolicy {
+	case StderrIgnore:
+		return nil
+	case StderrMatch:
+		expected, err := readFileOrEmpty(test.ExpectedStderrFile)
+		if err != nil {
+			return fmt.Errorf("%s: reading expected stderr: %w", test.Name, err)
+		}
+		if result.Stderr != expected {
+			return fmt.Errorf("%s: stderr mismatch\n--- expected ---\n%s\n--- actual ---\n%s", test.Name, expected, result.Stderr)
+		}
+		return nil
+	default: // StderrStrict
+		return fmt.Errorf("%s: unexpected stderr output:\n%s", test.Name, result.Stderr)
+	}
+}
@@ -0,0 +1,25 @@
+package bootstrap
+
+import "testing"
+
+func TestCheckResultStderrPolicies(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "ok\n"}
+	result := RunResult{Stdout: "ok\n", Stderr: "warning: deprecated\n"}
+
+	if err := CheckResult(test, result); err == nil {
+		t.Fatal("expected strict policy to fail on stderr output")
+	}
+
+	test.StderrPolicy = StderrIgnore
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("ignore policy should not fail: %v", err)
+	}
+}
+
+func TestCheckResultStdoutMismatch(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "ok\n", StderrPolicy: StderrIgnore}
+	result := RunResult{Stdout: "not ok\n"}
+	if err := CheckResult(test, result); err == nil {
+		t.Fatal("expected stdout mismatch to fail")
+	}
+}
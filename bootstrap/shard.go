@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ShardSpec selects one disjoint slice of a test set to run, out of
+// Count roughly-equal slices. Index is 1-indexed (the 2nd of 5 shards
+// is Index: 2, Count: 5). The zero value (Count 0) means no sharding.
+type ShardSpec struct {
+	Index, Count int
+}
+
+// ParseShardSpec parses a "-shard i/n" flag value like "2/5" into a
+// ShardSpec, validating that both halves are integers and that 1 <= i
+// <= n.
+func ParseShardSpec(spec string) (ShardSpec, error) {
+	parts := strings.SplitN(spec, "/", 2)
+	if len(parts) != 2 {
+		return ShardSpec{}, fmt.Errorf("invalid shard spec %q: want \"i/n\", e.g. \"2/5\"", spec)
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return ShardSpec{}, fmt.Errorf("invalid shard spec %q: %q is not an integer", spec, parts[0])
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return ShardSpec{}, fmt.Errorf("invalid shard spec %q: %q is not an integer", spec, parts[1])
+	}
+	if count < 1 || index < 1 || index > count {
+		return ShardSpec{}, fmt.Errorf("invalid shard spec %q: want 1 <= i <= n", spec)
+	}
+	return ShardSpec{Index: index, Count: count}, nil
+}
+
+// ShardTests returns the subset of tests belonging to shard, partitioning
+// the (already sorted) slice by index modulo shard.Count so that every
+// shard's subset is disjoint and their union is the whole input,
+// regardless of what order tests happens to be in. A zero-value shard
+// (Count 0) returns tests unchanged.
+func ShardTests(tests []*ScriptTest, shard ShardSpec) []*ScriptTest {
+	if shard.Count <= 0 {
+		return tests
+	}
+	var out []*ScriptTest
+	for i, test := range tests {
+		if i%shard.Count == shard.Index-1 {
+			out = append(out, test)
+		}
+	}
+	return out
+}
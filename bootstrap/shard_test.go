@@ -0,0 +1,53 @@
+package bootstrap
+
+import "testing"
+
+func TestParseShardSpecValid(t *testing.T) {
+	got, err := ParseShardSpec("2/5")
+	if err != nil {
+		t.Fatalf("ParseShardSpec(2/5) error: %v", err)
+	}
+	if got != (ShardSpec{Index: 2, Count: 5}) {
+		t.Fatalf("ParseShardSpec(2/5) = %+v, want {2 5}", got)
+	}
+}
+
+func TestParseShardSpecRejectsGarbage(t *testing.T) {
+	cases := []string{"", "2", "2/5/6", "a/5", "2/a", "0/5", "6/5", "-1/5"}
+	for _, c := range cases {
+		if _, err := ParseShardSpec(c); err == nil {
+			t.Errorf("ParseShardSpec(%q) = nil error, want error", c)
+		}
+	}
+}
+
+func TestShardTestsPartitionsExactly(t *testing.T) {
+	var all []*ScriptTest
+	for i := 0; i < 11; i++ {
+		all = append(all, &ScriptTest{Name: string(rune('a' + i))})
+	}
+
+	seen := make(map[string]int)
+	for i := 1; i <= 4; i++ {
+		for _, test := range ShardTests(all, ShardSpec{Index: i, Count: 4}) {
+			seen[test.Name]++
+		}
+	}
+
+	if len(seen) != len(all) {
+		t.Fatalf("shards covered %d of %d tests", len(seen), len(all))
+	}
+	for name, count := range seen {
+		if count != 1 {
+			t.Errorf("test %q was selected by %d shards, want exactly 1", name, count)
+		}
+	}
+}
+
+func TestShardTestsZeroCountReturnsAllUnchanged(t *testing.T) {
+	all := []*ScriptTest{{Name: "a"}, {Name: "b"}}
+	got := ShardTests(all, ShardSpec{})
+	if len(got) != len(all) {
+		t.Fatalf("ShardTests with zero Count = %d tests, want %d", len(got), len(all))
+	}
+}
@@ -0,0 +1,69 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// snapshotDir returns the set of regular files under dir, as paths
+// relative to dir, so checkNoSideEffects can diff what a test's run
+// added. A missing dir is treated as empty rather than an error, so a
+// test whose directory briefly doesn't exist yet doesn't crash the
+// snapshot.
+func snapshotDir(dir string) (map[string]bool, error) {
+	files := map[string]bool{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[rel] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// checkNoSideEffects compares before (a snapshot of test.Dir taken by
+// snapshotDir prior to running test) against the directory's current
+// contents, failing if any file exists now that didn't before and
+// isn't named by test.Creates — the existing "files this script is
+// expected to create" allow-list, see creates.go. It reports every
+// unexpected file at once rather than just the first, since a script
+// gone wrong may scatter several.
+func checkNoSideEffects(test *ScriptTest, before map[string]bool) error {
+	after, err := snapshotDir(test.Dir)
+	if err != nil {
+		return fmt.Errorf("%s: %w", test.Name, err)
+	}
+
+	var unexpected []string
+	for rel := range after {
+		if before[rel] {
+			continue
+		}
+		if _, allowed := test.Creates[rel]; allowed {
+			continue
+		}
+		unexpected = append(unexpected, rel)
+	}
+	if len(unexpected) == 0 {
+		return nil
+	}
+	sort.Strings(unexpected)
+	return fmt.Errorf("%s: unexpected file(s) created: %s", test.Name, strings.Join(unexpected, ", "))
+}
@@ -0,0 +1,91 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFakeInterpreter writes an executable shell script at path that
+// runs cmd (given $1 as the test script's own path), standing in for
+// the real byte interpreter binary in tests that need an actual
+// subprocess run.
+func writeFakeInterpreter(t *testing.T, path, cmd string) {
+	t.Helper()
+	writeFile(t, path, "#!/bin/sh\n"+cmd+"\n")
+	if err := os.Chmod(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunTestsNoSideEffectsFailsOnUnexpectedFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "messy.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "messy.out"), "")
+
+	binPath := filepath.Join(dir, "fake-byte")
+	writeFakeInterpreter(t, binPath, `touch "$(dirname "$1")/stray.txt"`)
+
+	summary := RunTests(TestOptions{
+		Dir:           dir,
+		BinPath:       binPath,
+		Quiet:         true,
+		NoSideEffects: true,
+	})
+	if summary.Failed != 1 {
+		t.Fatalf("Failed = %d, want 1 for a test that creates a stray file", summary.Failed)
+	}
+	if err := summary.Results[0].Err; err == nil || !strings.Contains(err.Error(), "stray.txt") {
+		t.Fatalf("Err = %v, want it to name stray.txt", err)
+	}
+}
+
+func TestRunTestsNoSideEffectsPassesWhenNothingNewAppears(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "clean.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "clean.out"), "")
+
+	binPath := filepath.Join(dir, "fake-byte")
+	writeFakeInterpreter(t, binPath, `true`)
+
+	summary := RunTests(TestOptions{
+		Dir:           dir,
+		BinPath:       binPath,
+		Quiet:         true,
+		NoSideEffects: true,
+	})
+	if summary.Failed != 0 {
+		t.Fatalf("Failed = %d, want 0 when the script creates nothing unexpected", summary.Failed)
+	}
+}
+
+func TestCheckNoSideEffectsAllowsFilesNamedByCreates(t *testing.T) {
+	dir := t.TempDir()
+	before, err := snapshotDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, filepath.Join(dir, "allowed.txt"), "ok")
+	test := &ScriptTest{Name: "t", Dir: dir, Creates: map[string]string{"allowed.txt": "ok"}}
+
+	if err := checkNoSideEffects(test, before); err != nil {
+		t.Fatalf("checkNoSideEffects = %v, want nil for a file named by Creates", err)
+	}
+}
+
+func TestCheckNoSideEffectsPassesWhenNothingNewAppears(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "existing.txt"), "already here")
+
+	before, err := snapshotDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test := &ScriptTest{Name: "t", Dir: dir}
+	if err := checkNoSideEffects(test, before); err != nil {
+		t.Fatalf("checkNoSideEffects = %v, want nil when nothing new appeared", err)
+	}
+}
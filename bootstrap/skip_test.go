@@ -0,0 +1,33 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsSkipDirectiveWithReason(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "flaky.byte"), "# skip: not implemented yet\nprint(1)")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || !tests[0].Skipped || tests[0].SkipReason != "not implemented yet" {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
+
+func TestDiscoverTestsSkipSidecar(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "flaky.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "flaky.skip"), "needs GC support")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || !tests[0].Skipped || tests[0].SkipReason != "needs GC support" {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
@@ -0,0 +1,16 @@
+package bootstrap
+
+import "testing"
+
+func TestRunTestsStrictFlagsZeroExecuted(t *testing.T) {
+	dir := t.TempDir()
+	summary := RunTests(TestOptions{Dir: dir, Strict: true})
+	if !summary.StrictViolation {
+		t.Fatal("expected StrictViolation for an empty suite")
+	}
+
+	lenient := RunTests(TestOptions{Dir: dir, Strict: false})
+	if lenient.StrictViolation {
+		t.Fatal("non-strict mode should never set StrictViolation")
+	}
+}
@@ -0,0 +1,46 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsStripAnsiDirective(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "colored.byte"), "# strip-ansi\nprint(1)")
+	writeFile(t, filepath.Join(dir, "colored.out"), "")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || !tests[0].StripANSI {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
+
+func TestCheckResultStripAnsiIgnoresColorCodes(t *testing.T) {
+	test := &ScriptTest{Name: "t", StripANSI: true, Expected: "hello\n"}
+	result := RunResult{Stdout: "\x1b[32mhello\x1b[0m\n"}
+
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("CheckResult = %v, want nil", err)
+	}
+}
+
+func TestCheckResultStripAnsiMismatchKeepsRawActual(t *testing.T) {
+	test := &ScriptTest{Name: "t", StripANSI: true, Expected: "hello\n"}
+	result := RunResult{Stdout: "\x1b[32mgoodbye\x1b[0m\n"}
+
+	err := CheckResult(test, result)
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("CheckResult error = %v (%T), want *MismatchError", err, err)
+	}
+	if mismatch.Actual != "goodbye\n" {
+		t.Fatalf("Actual = %q, want stripped %q", mismatch.Actual, "goodbye\n")
+	}
+	if mismatch.RawRunActual != result.Stdout {
+		t.Fatalf("RawRunActual = %q, want raw %q", mismatch.RawRunActual, result.Stdout)
+	}
+}
@@ -0,0 +1,71 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestDiscoverTestsStripPrefixDirective(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "logged.byte"), `# strip-prefix: \d{4}-\d{2}-\d{2} \w+ `+"\nprint(1)")
+	writeFile(t, filepath.Join(dir, "logged.out"), "")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || tests[0].StripPrefix == nil {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
+
+func TestCheckResultStripPrefixIgnoresTimestamp(t *testing.T) {
+	test := &ScriptTest{
+		Name:        "t",
+		StripPrefix: regexp.MustCompile(`^\d{4}-\d{2}-\d{2} INFO `),
+		Expected:    "starting\nstopping\n",
+	}
+	result := RunResult{Stdout: "2024-01-01 INFO starting\n2024-01-02 INFO stopping\n"}
+
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("CheckResult = %v, want nil", err)
+	}
+}
+
+func TestCheckResultStripPrefixMismatchKeepsRawForms(t *testing.T) {
+	test := &ScriptTest{
+		Name:        "t",
+		StripPrefix: regexp.MustCompile(`^\d{4}-\d{2}-\d{2} INFO `),
+		Expected:    "2024-01-01 INFO starting\n",
+	}
+	result := RunResult{Stdout: "2024-01-02 INFO stopping\n"}
+
+	err := CheckResult(test, result)
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("CheckResult error = %v (%T), want *MismatchError", err, err)
+	}
+	if mismatch.Expected != "starting\n" || mismatch.Actual != "stopping\n" {
+		t.Fatalf("stripped Expected/Actual = %q/%q, want %q/%q", mismatch.Expected, mismatch.Actual, "starting\n", "stopping\n")
+	}
+	if mismatch.RawExpected != test.Expected {
+		t.Fatalf("RawExpected = %q, want %q", mismatch.RawExpected, test.Expected)
+	}
+	if mismatch.RawRunActual != result.Stdout {
+		t.Fatalf("RawRunActual = %q, want %q", mismatch.RawRunActual, result.Stdout)
+	}
+}
+
+func TestCheckResultStripPrefixLinesWithoutMatchAreUnchanged(t *testing.T) {
+	test := &ScriptTest{
+		Name:        "t",
+		StripPrefix: regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `),
+		Expected:    "no timestamp here\n",
+	}
+	result := RunResult{Stdout: "no timestamp here\n"}
+
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("CheckResult = %v, want nil", err)
+	}
+}
@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunWithTeeReceivesStdoutAndStderr(t *testing.T) {
+	var tee bytes.Buffer
+	result, err := RunWith("sh", []string{"-c", "echo out; echo err >&2"}, RunOptions{Tee: &tee})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Stdout != "out\n" {
+		t.Fatalf("Stdout = %q, want %q", result.Stdout, "out\n")
+	}
+
+	got := tee.String()
+	if !strings.Contains(got, "out\n") || !strings.Contains(got, "err\n") {
+		t.Fatalf("tee = %q, want it to contain both streams", got)
+	}
+}
+
+func TestRunWithoutTeeLeavesItUntouched(t *testing.T) {
+	var tee bytes.Buffer
+	if _, err := RunWith("echo", []string{"hi"}, RunOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if tee.Len() != 0 {
+		t.Fatalf("tee.Len() = %d, want 0 when no Tee is configured", tee.Len())
+	}
+}
+
+func TestRunWithTeeFlushesABufferedWriter(t *testing.T) {
+	var backing bytes.Buffer
+	buffered := bufio.NewWriter(&backing)
+
+	if _, err := RunWith("echo", []string{"hi"}, RunOptions{Tee: buffered}); err != nil {
+		t.Fatal(err)
+	}
+
+	// If Write on the tee flushed as documented, the content already
+	// reached backing without the test having to call buffered.Flush()
+	// itself.
+	if backing.String() != "hi\n" {
+		t.Fatalf("backing = %q, want %q (tee should flush on every write)", backing.String(), "hi\n")
+	}
+}
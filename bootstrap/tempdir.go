@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+)
+
+// TempDir creates a new temporary directory named prefix-<random> under
+// the system temp root (via os.MkdirTemp) and returns its path along
+// with a cleanup func that removes the whole tree. The caller is
+// responsible for calling cleanup, typically via defer; WithTempDir
+// guarantees this even on panic.
+func TempDir(prefix string) (dir string, cleanup func(), err error) {
+	dir, err = os.MkdirTemp("", prefix+"-")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	return dir, func() { os.RemoveAll(dir) }, nil
+}
+
+// WithTempDir creates a temp dir the same way TempDir does, calls fn
+// with its path, and removes the directory afterward regardless of
+// whether fn returns an error or panics.
+func WithTempDir(prefix string, fn func(dir string) error) error {
+	dir, cleanup, err := TempDir(prefix)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return fn(dir)
+}
@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTempDirCreatesAndCleansUp(t *testing.T) {
+	dir, cleanup, err := TempDir("byte-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(filepath.Base(dir), "byte-test-") {
+		t.Fatalf("dir = %q, want it to contain the prefix", dir)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("TempDir's directory doesn't exist: %v", err)
+	}
+
+	cleanup()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("dir still exists after cleanup: %v", err)
+	}
+}
+
+func TestWithTempDirCleansUpOnSuccess(t *testing.T) {
+	var dir string
+	err := WithTempDir("byte-test", func(d string) error {
+		dir = d
+		return os.WriteFile(filepath.Join(d, "f.txt"), []byte("x"), 0644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("dir still exists after WithTempDir returned: %v", err)
+	}
+}
+
+func TestWithTempDirCleansUpOnPanic(t *testing.T) {
+	var dir string
+	func() {
+		defer func() { recover() }()
+		_ = WithTempDir("byte-test", func(d string) error {
+			dir = d
+			panic("boom")
+		})
+	}()
+
+	if dir == "" {
+		t.Fatal("fn was never called")
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("dir still exists after a panic: %v", err)
+	}
+}
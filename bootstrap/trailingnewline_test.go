@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckResultReportsMissingTrailingNewline(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "hello\n", StderrPolicy: StderrIgnore}
+	result := RunResult{Stdout: "hello"}
+
+	err := CheckResult(test, result)
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("err = %v (%T), want *MismatchError", err, err)
+	}
+	if !mismatch.TrailingNewlineOnly {
+		t.Fatal("TrailingNewlineOnly = false, want true")
+	}
+	if !strings.Contains(mismatch.Error(), "missing a trailing newline") {
+		t.Fatalf("Error() = %q, want it to name the missing trailing newline", mismatch.Error())
+	}
+}
+
+func TestCheckResultReportsExtraTrailingNewline(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "hello", StderrPolicy: StderrIgnore}
+	result := RunResult{Stdout: "hello\n"}
+
+	err := CheckResult(test, result)
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("err = %v (%T), want *MismatchError", err, err)
+	}
+	if !mismatch.TrailingNewlineOnly {
+		t.Fatal("TrailingNewlineOnly = false, want true")
+	}
+	if !strings.Contains(mismatch.Error(), "extra trailing newline") {
+		t.Fatalf("Error() = %q, want it to name the extra trailing newline", mismatch.Error())
+	}
+}
+
+func TestCheckResultDoesNotFlagUnrelatedMismatchesAsTrailingNewlineOnly(t *testing.T) {
+	test := &ScriptTest{Name: "t", Expected: "hello\n", StderrPolicy: StderrIgnore}
+	result := RunResult{Stdout: "goodbye\n"}
+
+	err := CheckResult(test, result)
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("err = %v (%T), want *MismatchError", err, err)
+	}
+	if mismatch.TrailingNewlineOnly {
+		t.Fatal("TrailingNewlineOnly = true for a real content mismatch")
+	}
+}
@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"strings"
+)
+
+// readFileOrEmpty reads path and returns its contents, or "" if path is
+// empty or doesn't exist.
+func readFileOrEmpty(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	return ReadText(path)
+}
+
+// TrimLines trims trailing whitespace from each line and drops trailing
+// empty lines, in place: it both overwrites lines[i] and returns a
+// truncated slice sharing its backing array, so the input slice is no
+// longer safe to use for anything other than the returned value.
+func TrimLines(lines []string) []string {
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	n := len(lines)
+	for n > 0 && lines[n-1] == "" {
+		n--
+	}
+	return lines[:n]
+}
+
+// TrimLinesCopy is like TrimLines but leaves lines untouched, returning
+// a new slice instead of mutating and truncating the input.
+func TrimLinesCopy(lines []string) []string {
+	out := make([]string, len(lines))
+	copy(out, lines)
+	return TrimLines(out)
+}
@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrimLinesCopyLeavesInputUntouched(t *testing.T) {
+	input := []string{"a  ", "b\t", "", ""}
+	original := append([]string(nil), input...)
+
+	got := TrimLinesCopy(input)
+
+	if !reflect.DeepEqual(input, original) {
+		t.Fatalf("TrimLinesCopy mutated its input: %v", input)
+	}
+	want := []string{"a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TrimLinesCopy = %v, want %v", got, want)
+	}
+}
+
+func TestTrimLinesMutatesAndTruncates(t *testing.T) {
+	input := []string{"a  ", "b\t", "", ""}
+	got := TrimLines(input)
+	want := []string{"a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TrimLines = %v, want %v", got, want)
+	}
+}
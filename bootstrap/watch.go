@@ -0,0 +1,113 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// watchSnapshot maps every regular file under root to its modification
+// time, for WatchTree to diff against the previous poll.
+func watchSnapshot(root string) (map[string]time.Time, error) {
+	snapshot := map[string]time.Time{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		snapshot[path] = info.ModTime()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// watchDiff returns the paths added, modified, or removed between two
+// snapshots taken by watchSnapshot.
+func watchDiff(prev, cur map[string]time.Time) []string {
+	var changed []string
+	for path, mtime := range cur {
+		if prevMtime, ok := prev[path]; !ok || !prevMtime.Equal(mtime) {
+			changed = append(changed, path)
+		}
+	}
+	for path := range prev {
+		if _, ok := cur[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// WatchTree polls the tree at root every interval, comparing file
+// modification times to detect files added, modified, or removed since
+// the last poll. Rather than calling onChange on every poll that sees a
+// change, it debounces: changes accumulate across polls and onChange
+// fires once, with the coalesced and deduplicated set of changed paths,
+// only after a poll finds nothing new — so a burst of edits (a save
+// that touches several files, or an editor writing a temp file then
+// renaming it) is reported as one notification instead of several.
+//
+// WatchTree returns a stop function; calling it terminates the polling
+// goroutine and blocks until it has exited, so no goroutine is leaked
+// after stop returns. A failed poll (e.g. root briefly missing during a
+// rebuild) is skipped rather than treated as a change or a fatal error.
+func WatchTree(root string, interval time.Duration, onChange func(changed []string)) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		prev, _ := watchSnapshot(root)
+		pending := map[string]bool{}
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				cur, err := watchSnapshot(root)
+				if err != nil {
+					continue
+				}
+				changed := watchDiff(prev, cur)
+				prev = cur
+
+				if len(changed) > 0 {
+					for _, path := range changed {
+						pending[path] = true
+					}
+					continue
+				}
+
+				if len(pending) == 0 {
+					continue
+				}
+				out := make([]string, 0, len(pending))
+				for path := range pending {
+					out = append(out, path)
+				}
+				sort.Strings(out)
+				pending = map[string]bool{}
+				onChange(out)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
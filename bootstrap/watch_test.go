@@ -0,0 +1,66 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchTreeCoalescesBurstIntoOneNotification(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.txt"), "1")
+
+	var mu sync.Mutex
+	var calls [][]string
+	// A generous poll interval relative to the sleeps below, so the
+	// burst of edits lands within a single poll window even if the
+	// goroutine is scheduled late under a loaded machine, instead of
+	// racing a poll that would otherwise split it into two
+	// notifications.
+	stop := WatchTree(dir, 100*time.Millisecond, func(changed []string) {
+		mu.Lock()
+		calls = append(calls, changed)
+		mu.Unlock()
+	})
+	defer stop()
+
+	// A burst of edits spread across a few milliseconds, well inside
+	// one poll interval, should still coalesce into a single
+	// notification once they stop.
+	time.Sleep(5 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	writeFile(t, filepath.Join(dir, "b.txt"), "new")
+
+	time.Sleep(500 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 1 {
+		t.Fatalf("got %d onChange calls, want 1: %v", len(calls), calls)
+	}
+	if len(calls[0]) != 2 {
+		t.Fatalf("coalesced change set = %v, want 2 paths", calls[0])
+	}
+}
+
+func TestWatchTreeStopLeavesNoGoroutineRunning(t *testing.T) {
+	dir := t.TempDir()
+	stop := WatchTree(dir, 5*time.Millisecond, func(changed []string) {})
+
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stop() did not return promptly")
+	}
+}
@@ -0,0 +1,29 @@
+package bootstrap
+
+import "testing"
+
+func TestLineWindowApply(t *testing.T) {
+	s := "a\nb\nc\nd\n"
+
+	w := &LineWindow{Start: 2, End: 3}
+	if got := w.apply(s); got != "b\nc\n" {
+		t.Fatalf("apply = %q, want %q", got, "b\nc\n")
+	}
+
+	w = &LineWindow{Start: 3}
+	if got := w.apply(s); got != "c\nd\n" {
+		t.Fatalf("apply = %q, want %q", got, "c\nd\n")
+	}
+}
+
+func TestCheckResultWindowedComparison(t *testing.T) {
+	test := &ScriptTest{
+		Name:     "t",
+		Expected: "noise\nsignal\nmore noise\n",
+		Window:   &LineWindow{Start: 2, End: 2},
+	}
+	result := RunResult{Stdout: "different noise\nsignal\nother noise\n"}
+	if err := CheckResult(test, result); err != nil {
+		t.Fatalf("windowed comparison should pass: %v", err)
+	}
+}
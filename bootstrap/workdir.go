@@ -0,0 +1,64 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// metaExt is the extension for a script's small JSON metadata sidecar,
+// see loadScriptMeta.
+const metaExt = ".meta.json"
+
+// scriptMeta is the shape of a `<name>.meta.json` sidecar.
+type scriptMeta struct {
+	// WorkDir, relative to the script's own directory, overrides where
+	// the script is run — see ScriptTest.WorkDir.
+	WorkDir string `json:"workdir"`
+}
+
+// loadScriptMeta reads scriptPath's `<name>.meta.json` sidecar, if any.
+// A missing sidecar returns the zero value and a nil error.
+func loadScriptMeta(scriptPath string) (scriptMeta, error) {
+	path := strings.TrimSuffix(scriptPath, scriptExt) + metaExt
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return scriptMeta{}, nil
+		}
+		return scriptMeta{}, err
+	}
+
+	var meta scriptMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return scriptMeta{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return meta, nil
+}
+
+// workDirDirective resolves scriptPath's `.meta.json` workdir field (if
+// any) to an absolute directory relative to the script's own
+// directory, validating that it exists. It returns "" when the script
+// has no sidecar or no workdir field, so the caller falls back to its
+// own directory.
+func workDirDirective(scriptPath string) (string, error) {
+	meta, err := loadScriptMeta(scriptPath)
+	if err != nil {
+		return "", err
+	}
+	if meta.WorkDir == "" {
+		return "", nil
+	}
+
+	dir := filepath.Join(filepath.Dir(scriptPath), meta.WorkDir)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("%s: workdir %q: %w", scriptPath, meta.WorkDir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s: workdir %q is not a directory", scriptPath, meta.WorkDir)
+	}
+	return dir, nil
+}
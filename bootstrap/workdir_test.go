@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverTestsLoadsWorkDirFromMetaSidecar(t *testing.T) {
+	dir := t.TempDir()
+	fixtures := filepath.Join(dir, "fixtures")
+	if err := os.Mkdir(fixtures, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "t.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "t.out"), "")
+	writeFile(t, filepath.Join(dir, "t.meta.json"), `{"workdir": "fixtures"}`)
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || tests[0].WorkDir != fixtures {
+		t.Fatalf("unexpected discovery result: %+v, want WorkDir %q", tests, fixtures)
+	}
+}
+
+func TestDiscoverTestsWithoutMetaSidecarLeavesWorkDirEmpty(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "t.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "t.out"), "")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || tests[0].WorkDir != "" {
+		t.Fatalf("unexpected discovery result: %+v, want empty WorkDir", tests)
+	}
+}
+
+func TestDiscoverTestsRejectsAMissingWorkDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "t.byte"), "print(1)")
+	writeFile(t, filepath.Join(dir, "t.out"), "")
+	writeFile(t, filepath.Join(dir, "t.meta.json"), `{"workdir": "does-not-exist"}`)
+
+	if _, err := discoverTests(dir); err == nil {
+		t.Fatal("discoverTests = nil error, want one naming the missing workdir")
+	}
+}
+
+func TestRunScriptHonorsWorkDirOverride(t *testing.T) {
+	scriptDir := t.TempDir()
+	workDir := t.TempDir()
+
+	binPath := filepath.Join(scriptDir, "fake-byte")
+	writeFakeInterpreter(t, binPath, `pwd`)
+
+	test := &ScriptTest{Name: "t", Dir: scriptDir, Source: filepath.Join(scriptDir, "t.byte"), WorkDir: workDir}
+	result, err := runScript(context.Background(), binPath, test, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := filepath.EvalSymlinks(strings.TrimRight(result.Stdout, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := filepath.EvalSymlinks(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("ran in %q, want WorkDir %q", got, want)
+	}
+}
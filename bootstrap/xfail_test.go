@@ -0,0 +1,56 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTestsXFailDirectiveWithReason(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "broken.byte"), "# xfail: known parser bug\nprint(1)")
+	writeFile(t, filepath.Join(dir, "broken.out"), "")
+
+	tests, err := discoverTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 || !tests[0].XFail || tests[0].XFailReason != "known parser bug" {
+		t.Fatalf("unexpected discovery result: %+v", tests)
+	}
+}
+
+func TestRunTestsXFailReportedNotAsFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "broken.byte", "# xfail: known parser bug\n")
+	if err := os.WriteFile(filepath.Join(dir, "broken.out"), []byte("expected but never produced\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := RunTests(TestOptions{Dir: dir, BinPath: "true", Quiet: true})
+	if summary.Failed != 0 {
+		t.Fatalf("Failed = %d, want 0 since the failure was expected", summary.Failed)
+	}
+	if summary.XFailed != 1 {
+		t.Fatalf("XFailed = %d, want 1", summary.XFailed)
+	}
+}
+
+func TestRunTestsXPassUnderStrictIsViolation(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "fixed.byte", "# xfail: thought this was broken\n")
+	if err := os.WriteFile(filepath.Join(dir, "fixed.out"), []byte(""), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := RunTests(TestOptions{Dir: dir, BinPath: "true", Quiet: true, Strict: true})
+	if summary.XPassed != 1 {
+		t.Fatalf("XPassed = %d, want 1", summary.XPassed)
+	}
+	if summary.Passed != 1 {
+		t.Fatalf("Passed = %d, want 1 (xpass still counts as a pass)", summary.Passed)
+	}
+	if !summary.StrictViolation {
+		t.Fatal("expected StrictViolation under -strict when a test unexpectedly xpasses")
+	}
+}
@@ -0,0 +1,225 @@
+// Command byte is the project's bootstrap tool: it builds the byte
+// interpreter via cargo and drives the script-based test suite against
+// it. Its `check` verb also golden-tests arbitrary commands, not just
+// `.byte` scripts, against an expected-output file.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ax-lab/byte/bootstrap"
+)
+
+// Exit code 130 is the conventional "killed by SIGINT" status (128 +
+// signal number 2), matching what a shell reports when Ctrl-C kills a
+// foreground process outright.
+const interruptExitCode = 130
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("usage: byte <verb> [args...]")
+		os.Exit(1)
+	}
+
+	switch verb := os.Args[1]; verb {
+	case "test":
+		runTestVerb()
+	case "check":
+		runCheckVerb()
+	default:
+		fmt.Printf("unknown verb: %s\n", verb)
+		os.Exit(1)
+	}
+}
+
+// runCheckVerb runs an arbitrary command and compares its stdout
+// against an expected file, using the same comparison and diff
+// machinery as `byte test`, for golden-testing things that aren't
+// `.byte` scripts.
+func runCheckVerb() {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	expectPath := flags.String("expect", "", "path to the file holding the command's expected stdout (required)")
+	stripAnsi := flags.Bool("strip-ansi", false, "strip ANSI escape sequences from the command's stdout before comparison")
+	flags.Parse(os.Args[2:])
+
+	args := flags.Args()
+	if len(args) == 0 {
+		fmt.Println("usage: byte check [flags] <command> [args...]")
+		os.Exit(1)
+	}
+	if *expectPath == "" {
+		fmt.Println("error: -expect is required")
+		os.Exit(1)
+	}
+
+	expected, err := bootstrap.ReadText(*expectPath)
+	if err != nil {
+		fmt.Printf("error: could not read %s: %v\n", *expectPath, err)
+		os.Exit(1)
+	}
+
+	result, err := bootstrap.Run(args[0], args[1:]...)
+	if err != nil {
+		fmt.Printf("error: could not run %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+
+	test := &bootstrap.ScriptTest{Name: args[0], Expected: expected, StripANSI: *stripAnsi}
+	if err := bootstrap.CheckResult(test, result); err != nil {
+		if mismatch, ok := err.(*bootstrap.MismatchError); ok {
+			mismatch.WriteUnified(os.Stdout, false)
+		} else {
+			fmt.Println(err)
+		}
+		os.Exit(1)
+	}
+	fmt.Println("OK")
+}
+
+// runTestVerb runs the test suite and formats the resulting summary. The
+// discovery/run/check logic itself lives in bootstrap.RunTests so it can
+// be invoked (and tested) outside of this command.
+func runTestVerb() {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	verbose := flags.Bool("v", true, "print a banner and PASS! line for every test, not just failures")
+	quiet := flags.Bool("quiet", false, "suppress the banner and PASS! line for passing tests")
+	strict := flags.Bool("strict", false, "fail the run if zero tests executed, any test was skipped, `# only` deselected tests, or an xfail test passed")
+	jsonSummaryPath := flags.String("json-summary", "", "write a machine-readable JSON summary to this path")
+	artifactsDir := flags.String("artifacts", "", "write each test's stdout/stderr/exit code under this directory for post-mortem debugging")
+	aggregate := flags.Bool("aggregate", false, "replace per-test diff output with a single report grouping failures by the kind of difference")
+	stripAnsi := flags.Bool("strip-ansi", false, "strip ANSI escape sequences from every test's stdout before comparison")
+	shard := flags.String("shard", "", "run only one disjoint slice of the suite, as \"i/n\" (e.g. \"2/5\"), for splitting across CI machines")
+	createMissing := flags.Bool("create-missing", false, "instead of running tests, create a `.out` file for every script that has no expectation yet, from its current output")
+	list := flags.Bool("list", false, "list discovered tests and their expected-output kind instead of running them")
+	noBuild := flags.Bool("no-build", false, "skip the cargo build step and run tests against whatever binary already exists (also settable via SKIP_BUILD)")
+	noSideEffects := flags.Bool("no-side-effects", false, "fail a test if its run creates a file that isn't named by its `.creates.json` allow-list")
+	envAllowlist := flags.String("env-allowlist", "", "run scripts with only these comma-separated environment variable names inherited, instead of the full environment (e.g. \"PATH,HOME\")")
+	redact := flags.String("redact", "", "apply these comma-separated built-in redactors (e.g. \"ptr,uuid\") to every test's expected and actual output before comparison")
+	bail := flags.Int("bail", 0, "stop launching new tests once this many have failed, leaving the rest unrun (0 disables, running everything)")
+	flags.Parse(os.Args[2:])
+
+	var allowlist []string
+	if *envAllowlist != "" {
+		allowlist = strings.Split(*envAllowlist, ",")
+	}
+
+	var redactors []bootstrap.Redactor
+	if *redact != "" {
+		for _, name := range strings.Split(*redact, ",") {
+			r, ok := bootstrap.RedactorByName(name)
+			if !ok {
+				fmt.Printf("error: unknown redactor %q\n", name)
+				os.Exit(1)
+			}
+			redactors = append(redactors, r)
+		}
+	}
+
+	if *list {
+		infos, err := bootstrap.ListTests(".")
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, info := range infos {
+			switch {
+			case info.Err != nil:
+				fmt.Printf("%s\terror: %v\n", info.Name, info.Err)
+			case info.Skipped:
+				fmt.Printf("%s\tskipped\n", info.Name)
+			default:
+				fmt.Printf("%s\t%s\n", info.Name, info.Kind)
+			}
+		}
+		return
+	}
+
+	binPath := "./target/debug/byte"
+	binPath, err := bootstrap.BootWithOptions(binPath, ".", bootstrap.BootOptions{Skip: *noBuild})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var shardSpec bootstrap.ShardSpec
+	if *shard != "" {
+		var err error
+		shardSpec, err = bootstrap.ParseShardSpec(*shard)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// The first Ctrl-C stops launching new tests and cancels whichever
+	// one is currently running; a second means the user wants out now,
+	// without waiting for that cancellation to finish.
+	sig := make(chan os.Signal, 2)
+	signal.Notify(sig, os.Interrupt)
+	defer signal.Stop(sig)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if _, ok := <-sig; !ok {
+			return
+		}
+		cancel()
+		if _, ok := <-sig; ok {
+			os.Exit(interruptExitCode)
+		}
+	}()
+
+	if *createMissing {
+		created, err := bootstrap.CreateMissing(ctx, ".", binPath, os.Stdout)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\n%d expectation(s) created\n", len(created))
+		return
+	}
+
+	start := time.Now()
+	summary := bootstrap.RunTests(bootstrap.TestOptions{
+		Dir:           ".",
+		BinPath:       binPath,
+		Quiet:         *quiet || !*verbose,
+		Strict:        *strict,
+		ArtifactsDir:  *artifactsDir,
+		Aggregate:     *aggregate,
+		StripANSI:     *stripAnsi,
+		NoSideEffects: *noSideEffects,
+		EnvAllowlist:  allowlist,
+		Redactors:     redactors,
+		Bail:          *bail,
+		Shard:         shardSpec,
+		Context:       ctx,
+	})
+	duration := time.Since(start)
+
+	fmt.Printf("\n%d total, %d passed, %d failed, %d skipped\n",
+		summary.Total, summary.Passed, summary.Failed, summary.Skipped)
+	if summary.XFailed > 0 || summary.XPassed > 0 {
+		fmt.Printf("%d xfailed, %d xpassed\n", summary.XFailed, summary.XPassed)
+	}
+
+	if *jsonSummaryPath != "" {
+		if err := bootstrap.WriteJSONSummary(*jsonSummaryPath, summary, duration); err != nil {
+			fmt.Printf("error: could not write json summary: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if summary.Interrupted {
+		os.Exit(interruptExitCode)
+	}
+	if summary.Failed > 0 || summary.StrictViolation {
+		os.Exit(1)
+	}
+}
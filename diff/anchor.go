@@ -0,0 +1,137 @@
+package diff
+
+// defaultMinCandidates and defaultMaxCandidates are the adaptive
+// candidate budget's bounds when CompareOptions.MinCandidates/
+// MaxCandidates are left at their zero value.
+const (
+	defaultMinCandidates = 64
+	defaultMaxCandidates = 4000
+)
+
+// candidateBudget returns how many anchor candidates to keep under
+// opts.AdaptiveCandidates: min(len(a), len(b)) clamped to
+// [MinCandidates, MaxCandidates].
+func candidateBudget(a, b []string, opts CompareOptions) int {
+	min, max := opts.MinCandidates, opts.MaxCandidates
+	if min <= 0 {
+		min = defaultMinCandidates
+	}
+	if max <= 0 {
+		max = defaultMaxCandidates
+	}
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	switch {
+	case n < min:
+		return min
+	case n > max:
+		return max
+	default:
+		return n
+	}
+}
+
+// sampleCandidates returns at most budget candidates evenly spaced
+// across candidates, preserving their original order (sorted by bi),
+// so a downsampled candidate set still anchors across the whole input
+// instead of clustering near the start.
+func sampleCandidates(candidates []anchor, budget int) []anchor {
+	if budget <= 0 || len(candidates) <= budget {
+		return candidates
+	}
+	out := make([]anchor, budget)
+	stride := float64(len(candidates)) / float64(budget)
+	for i := range out {
+		out[i] = candidates[int(float64(i)*stride)]
+	}
+	return out
+}
+
+// anchor is a pair of positions in a and b known to hold the same,
+// globally-unique line.
+type anchor struct{ ai, bi int }
+
+// anchoredEdits diffs a and b using unique lines shared by both as fixed
+// match points, falling back to the regular algorithm on the gaps
+// between them.
+func anchoredEdits(a, b []string, ws *Workspace, opts CompareOptions) []editOp {
+	countA := map[string]int{}
+	for _, l := range a {
+		countA[l]++
+	}
+	countB := map[string]int{}
+	for _, l := range b {
+		countB[l]++
+	}
+
+	indexInA := map[string]int{}
+	for i, l := range a {
+		if countA[l] == 1 {
+			indexInA[l] = i
+		}
+	}
+
+	var candidates []anchor
+	for j, l := range b {
+		if countB[l] != 1 {
+			continue
+		}
+		if i, ok := indexInA[l]; ok {
+			candidates = append(candidates, anchor{i, j})
+		}
+	}
+
+	if opts.AdaptiveCandidates {
+		candidates = sampleCandidates(candidates, candidateBudget(a, b, opts))
+	}
+	anchors := longestIncreasingByA(candidates)
+
+	var ops []editOp
+	prevA, prevB := 0, 0
+	for _, anc := range anchors {
+		ops = append(ops, computeLCS(a[prevA:anc.ai], b[prevB:anc.bi], ws)...)
+		ops = append(ops, editOp{op: Equal, line: a[anc.ai]})
+		prevA, prevB = anc.ai+1, anc.bi+1
+	}
+	ops = append(ops, computeLCS(a[prevA:], b[prevB:], ws)...)
+	return ops
+}
+
+// longestIncreasingByA returns the longest subsequence of candidates
+// (already sorted by bi, since they were collected in b's order) whose
+// ai values are also strictly increasing, so the anchors form a valid,
+// order-preserving alignment between a and b.
+func longestIncreasingByA(candidates []anchor) []anchor {
+	n := len(candidates)
+	if n == 0 {
+		return nil
+	}
+
+	length := make([]int, n)
+	prev := make([]int, n)
+	best := 0
+	for i := range candidates {
+		length[i] = 1
+		prev[i] = -1
+		for j := 0; j < i; j++ {
+			if candidates[j].ai < candidates[i].ai && length[j]+1 > length[i] {
+				length[i] = length[j] + 1
+				prev[i] = j
+			}
+		}
+		if length[i] > length[best] {
+			best = i
+		}
+	}
+
+	var out []anchor
+	for i := best; i != -1; i = prev[i] {
+		out = append(out, candidates[i])
+	}
+	for l, r := 0, len(out)-1; l < r; l, r = l+1, r-1 {
+		out[l], out[r] = out[r], out[l]
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package diff
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompareWithOptionsUniqueAnchorsRoundTrips(t *testing.T) {
+	a := []string{"x", "x", "x", "UNIQUE_A", "x", "x"}
+	b := []string{"x", "x", "UNIQUE_A", "x", "x", "x"}
+
+	blocks := CompareWithOptions(a, b, CompareOptions{UniqueAnchors: true})
+	gotA, gotB := linesOf(blocks)
+	if len(gotA) != len(a) || len(gotB) != len(b) {
+		t.Fatalf("CompareWithOptions did not round-trip: %v / %v", gotA, gotB)
+	}
+	for i := range gotA {
+		if gotA[i] != a[i] {
+			t.Fatalf("reconstructed a = %v, want %v", gotA, a)
+		}
+	}
+	for i := range gotB {
+		if gotB[i] != b[i] {
+			t.Fatalf("reconstructed b = %v, want %v", gotB, b)
+		}
+	}
+}
+
+func TestCandidateBudgetScalesWithInputSizeWithinBounds(t *testing.T) {
+	small := make([]string, 8)
+	if got := candidateBudget(small, small, CompareOptions{}); got != defaultMinCandidates {
+		t.Fatalf("candidateBudget(small) = %d, want the default minimum %d", got, defaultMinCandidates)
+	}
+
+	huge := make([]string, defaultMaxCandidates*2)
+	if got := candidateBudget(huge, huge, CompareOptions{}); got != defaultMaxCandidates {
+		t.Fatalf("candidateBudget(huge) = %d, want the default maximum %d", got, defaultMaxCandidates)
+	}
+
+	medium := make([]string, 500)
+	if got := candidateBudget(medium, medium, CompareOptions{}); got != 500 {
+		t.Fatalf("candidateBudget(medium) = %d, want len(a) itself (500), unclamped", got)
+	}
+
+	opts := CompareOptions{MinCandidates: 10, MaxCandidates: 20}
+	if got := candidateBudget(small, small, opts); got != 10 {
+		t.Fatalf("candidateBudget with custom MinCandidates = %d, want 10", got)
+	}
+	if got := candidateBudget(huge, huge, opts); got != 20 {
+		t.Fatalf("candidateBudget with custom MaxCandidates = %d, want 20", got)
+	}
+}
+
+func TestCompareWithOptionsAdaptiveCandidatesRoundTrips(t *testing.T) {
+	a := make([]string, 0, 200)
+	b := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		a = append(a, fmt.Sprintf("UNIQUE_%d", i))
+		b = append(b, fmt.Sprintf("UNIQUE_%d", i))
+	}
+
+	blocks := CompareWithOptions(a, b, CompareOptions{
+		UniqueAnchors:      true,
+		AdaptiveCandidates: true,
+		MinCandidates:      1,
+		MaxCandidates:      16,
+	})
+	gotA, gotB := linesOf(blocks)
+	if len(gotA) != len(a) || len(gotB) != len(b) {
+		t.Fatalf("CompareWithOptions did not round-trip with a downsampled candidate set: %v / %v", gotA, gotB)
+	}
+	for i := range gotA {
+		if gotA[i] != a[i] || gotB[i] != b[i] {
+			t.Fatalf("reconstructed inputs diverged at %d: %q/%q, want %q/%q", i, gotA[i], gotB[i], a[i], b[i])
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package diff
+
+import "regexp"
+
+// ansiEscape matches a terminal escape sequence: ESC followed by `[`,
+// any number of parameter/intermediate bytes, and a final letter. This
+// covers SGR color codes (`\x1b[31m`) as well as cursor-movement codes
+// (`\x1b[2K`, `\x1b[1;1H`, ...).
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;?]*[a-zA-Z]")
+
+// StripANSI removes terminal escape sequences from s, so output colored
+// for a human reader can still be compared against a golden file that
+// doesn't (and shouldn't) encode that coloring.
+func StripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
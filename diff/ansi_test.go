@@ -0,0 +1,24 @@
+package diff
+
+import "testing"
+
+func TestStripANSIColorCodes(t *testing.T) {
+	s := "\x1b[31merror\x1b[0m: bad input"
+	if got := StripANSI(s); got != "error: bad input" {
+		t.Fatalf("StripANSI = %q, want %q", got, "error: bad input")
+	}
+}
+
+func TestStripANSICursorMovement(t *testing.T) {
+	s := "\x1b[2K\x1b[1;1Hloading...\x1b[1A"
+	if got := StripANSI(s); got != "loading..." {
+		t.Fatalf("StripANSI = %q, want %q", got, "loading...")
+	}
+}
+
+func TestStripANSINoEscapesUnchanged(t *testing.T) {
+	s := "plain text"
+	if got := StripANSI(s); got != s {
+		t.Fatalf("StripANSI = %q, want unchanged %q", got, s)
+	}
+}
@@ -0,0 +1,16 @@
+package diff
+
+// Apply reconstructs the "b" sequence that blocks were produced from,
+// by concatenating every Equal and Insert block's Lines in order and
+// skipping Delete blocks. It's the inverse of Compare: Apply(Compare(a,
+// b)) reproduces b.
+func Apply(blocks []DiffBlock) []string {
+	var out []string
+	for _, blk := range blocks {
+		if blk.Op == Delete {
+			continue
+		}
+		out = append(out, blk.Lines...)
+	}
+	return out
+}
@@ -0,0 +1,22 @@
+package diff
+
+// TestingT is the minimal subset of *testing.T that AssertDiff needs,
+// so it can be used from any test framework without importing
+// "testing" here.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertDiff fails t via Errorf, printing a unified diff of expected
+// vs actual (through DiffText), if the two strings differ. It reports
+// whether they matched, so callers can chain it into further
+// assertions. Equal strings produce no output at all.
+func AssertDiff(t TestingT, expected, actual string) bool {
+	t.Helper()
+	if expected == actual {
+		return true
+	}
+	t.Errorf("unexpected diff (-expected +actual):\n%s", DiffText(expected, actual, 3))
+	return false
+}
@@ -0,0 +1,44 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeT is a minimal TestingT that records Errorf calls instead of
+// failing the real test, so AssertDiff's behavior can be asserted on
+// directly.
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertDiffPassesOnEqualStrings(t *testing.T) {
+	var ft fakeT
+	if !AssertDiff(&ft, "same\n", "same\n") {
+		t.Fatal("AssertDiff = false for equal strings")
+	}
+	if len(ft.errors) != 0 {
+		t.Fatalf("Errorf called %d time(s), want 0", len(ft.errors))
+	}
+}
+
+func TestAssertDiffFailsWithReadableDiffOnMismatch(t *testing.T) {
+	var ft fakeT
+	if AssertDiff(&ft, "line one\nline two\n", "line one\nline TWO\n") {
+		t.Fatal("AssertDiff = true for differing strings")
+	}
+	if len(ft.errors) != 1 {
+		t.Fatalf("Errorf called %d time(s), want 1", len(ft.errors))
+	}
+	got := ft.errors[0]
+	if !strings.Contains(got, "-line two") || !strings.Contains(got, "+line TWO") {
+		t.Fatalf("error = %q, want a unified diff of the two lines", got)
+	}
+}
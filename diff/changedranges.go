@@ -0,0 +1,38 @@
+package diff
+
+// LineRange is a span of lines in a new-file version, 1-indexed and
+// inclusive of Count lines starting at Start. A Count of 0 marks a
+// point (e.g. a pure deletion) rather than a span.
+type LineRange struct {
+	Start, Count int
+}
+
+// ChangedRanges returns the line ranges in b that changed relative to
+// a: each inserted block's span, and the destination span of a
+// replaced region (a Delete immediately followed by an Insert — the
+// Insert's range already covers it). A Delete with no adjacent Insert
+// is a pure deletion with nothing to highlight in b, so it's reported
+// as a zero-length LineRange at the point it was removed, for an
+// editor to still draw a gutter marker there.
+func ChangedRanges(a, b []string) []LineRange {
+	return changedRangesFromBlocks(Compare(a, b))
+}
+
+func changedRangesFromBlocks(blocks []DiffBlock) []LineRange {
+	var ranges []LineRange
+	bLine := 1
+	for i, blk := range blocks {
+		switch blk.Op {
+		case Equal:
+			bLine += len(blk.Lines)
+		case Insert:
+			ranges = append(ranges, LineRange{Start: bLine, Count: len(blk.Lines)})
+			bLine += len(blk.Lines)
+		case Delete:
+			if i+1 >= len(blocks) || blocks[i+1].Op != Insert {
+				ranges = append(ranges, LineRange{Start: bLine, Count: 0})
+			}
+		}
+	}
+	return ranges
+}
@@ -0,0 +1,43 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedRangesInsertion(t *testing.T) {
+	a := []string{"one", "three"}
+	b := []string{"one", "two", "three"}
+	got := ChangedRanges(a, b)
+	want := []LineRange{{Start: 2, Count: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ChangedRanges = %v, want %v", got, want)
+	}
+}
+
+func TestChangedRangesPureDeletion(t *testing.T) {
+	a := []string{"one", "two", "three"}
+	b := []string{"one", "three"}
+	got := ChangedRanges(a, b)
+	want := []LineRange{{Start: 2, Count: 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ChangedRanges = %v, want %v", got, want)
+	}
+}
+
+func TestChangedRangesReplace(t *testing.T) {
+	a := []string{"one", "two", "three"}
+	b := []string{"one", "TWO", "three"}
+	got := ChangedRanges(a, b)
+	want := []LineRange{{Start: 2, Count: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ChangedRanges = %v, want %v", got, want)
+	}
+}
+
+func TestChangedRangesNoChanges(t *testing.T) {
+	a := []string{"one", "two"}
+	if got := ChangedRanges(a, a); len(got) != 0 {
+		t.Fatalf("ChangedRanges = %v, want none", got)
+	}
+}
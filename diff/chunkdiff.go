@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"hash/fnv"
+	"strconv"
+)
+
+// chunkDiffMinLines/MaxLines bound content-defined chunk sizes so a
+// pathological input (a boundary hash that never matches, or matches
+// constantly) can't degrade to one giant chunk or one chunk per line.
+const (
+	chunkDiffMinLines = 16
+	chunkDiffMaxLines = 256
+	// chunkDiffMask selects roughly 1 in 64 lines as a boundary
+	// candidate, targeting an average chunk size well inside
+	// [chunkDiffMinLines, chunkDiffMaxLines].
+	chunkDiffMask = 63
+)
+
+// lineChunk is a contiguous run [start, end) of lines treated as one
+// unit by chunkDiffCompare's first pass.
+type lineChunk struct {
+	start, end int
+}
+
+// chunkLines splits lines into content-defined chunks using a rolling
+// boundary rule: a chunk ends after any line whose hash, masked by
+// chunkDiffMask, is zero, except within chunkDiffMinLines of the
+// chunk's start (to avoid tiny chunks) or once chunkDiffMaxLines is
+// reached (to cap a chunk when no boundary hash ever matches). Because
+// the boundary rule depends only on line content, not position, the
+// same repeated run of lines produces the same chunk boundaries
+// wherever it appears in a or b — the property chunkDiffCompare relies
+// on to recognize large unchanged regions cheaply.
+func chunkLines(lines []string) []lineChunk {
+	var chunks []lineChunk
+	start := 0
+	for i, line := range lines {
+		size := i - start + 1
+		if (size >= chunkDiffMinLines && fnvHash32(line)&chunkDiffMask == 0) || size >= chunkDiffMaxLines {
+			chunks = append(chunks, lineChunk{start: start, end: i + 1})
+			start = i + 1
+		}
+	}
+	if start < len(lines) {
+		chunks = append(chunks, lineChunk{start: start, end: len(lines)})
+	}
+	return chunks
+}
+
+// fnvHash32 hashes a single line for chunkLines' boundary test.
+func fnvHash32(line string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(line))
+	return h.Sum32()
+}
+
+// chunkKey returns a content-addressed key for chunk c's lines within
+// lines, so two chunks with identical content (and only those) collapse
+// to the same key regardless of where they sit in the sequence.
+func chunkKey(lines []string, c lineChunk) string {
+	h := fnv.New64a()
+	for _, line := range lines[c.start:c.end] {
+		h.Write([]byte(line))
+		h.Write([]byte{0})
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// chunkDiffCompare is the content-defined-chunking counterpart to
+// Compare, selected by CompareOptions{ChunkDiff: true}. It first chunks
+// both inputs with chunkLines and diffs the chunks' content hashes
+// instead of the raw lines, so a large unchanged region collapses to a
+// handful of matching chunk keys rather than thousands of line
+// comparisons; only the lines inside a changed run of chunks go through
+// the precise computeLCS. The result is always a correct edit script —
+// every line of a and b is accounted for, via either a matched chunk or
+// a precisely diffed one — but it is not guaranteed minimal right at a
+// chunk boundary, since a real change straddling two chunks forces both
+// chunks (and everything between them) into the precise pass even if
+// part of that span didn't actually change. Collisions between
+// differing chunks' 64-bit keys are astronomically unlikely and are not
+// guarded against, the same tradeoff rolling-hash content chunking
+// (e.g. rsync, restic) always makes.
+func chunkDiffCompare(a, b []string) []DiffBlock {
+	ca := chunkLines(a)
+	cb := chunkLines(b)
+
+	keysA := make([]string, len(ca))
+	for i, c := range ca {
+		keysA[i] = chunkKey(a, c)
+	}
+	keysB := make([]string, len(cb))
+	for i, c := range cb {
+		keysB[i] = chunkKey(b, c)
+	}
+
+	chunkBlocks := Compare(keysA, keysB)
+
+	var ops []editOp
+	ai, bi := 0, 0
+	i := 0
+	for i < len(chunkBlocks) {
+		block := chunkBlocks[i]
+		if block.Op == Equal {
+			for range block.Lines {
+				c := ca[ai]
+				for _, line := range a[c.start:c.end] {
+					ops = append(ops, editOp{op: Equal, line: line})
+				}
+				ai++
+				bi++
+			}
+			i++
+			continue
+		}
+
+		var delChunks, insChunks int
+		for i < len(chunkBlocks) && chunkBlocks[i].Op != Equal {
+			if chunkBlocks[i].Op == Delete {
+				delChunks += len(chunkBlocks[i].Lines)
+			} else {
+				insChunks += len(chunkBlocks[i].Lines)
+			}
+			i++
+		}
+
+		var aSub, bSub []string
+		if delChunks > 0 {
+			aSub = a[ca[ai].start:ca[ai+delChunks-1].end]
+		}
+		if insChunks > 0 {
+			bSub = b[cb[bi].start:cb[bi+insChunks-1].end]
+		}
+		ops = append(ops, computeLCS(aSub, bSub, &Workspace{})...)
+		ai += delChunks
+		bi += insChunks
+	}
+
+	return buildBlocks(ops)
+}
@@ -0,0 +1,100 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func applyBlocks(blocks []DiffBlock) (from, to []string) {
+	for _, block := range blocks {
+		switch block.Op {
+		case Equal:
+			from = append(from, block.Lines...)
+			to = append(to, block.Lines...)
+		case Delete:
+			from = append(from, block.Lines...)
+		case Insert:
+			to = append(to, block.Lines...)
+		}
+	}
+	return from, to
+}
+
+func bigLines(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("line %d", i)
+	}
+	return out
+}
+
+func TestChunkDiffCompareProducesAValidEditScript(t *testing.T) {
+	a := bigLines(2000)
+	b := bigLines(2000)
+	b[100] = "changed-100"
+	b = append(b[:1000], append([]string{"inserted"}, b[1000:]...)...)
+	b[1900] = "changed-1900"
+
+	blocks := CompareWithOptions(a, b, CompareOptions{ChunkDiff: true})
+	from, to := applyBlocks(blocks)
+	if !reflect.DeepEqual(from, a) {
+		t.Fatal("edit script's deleted+equal lines don't reconstruct a")
+	}
+	if !reflect.DeepEqual(to, b) {
+		t.Fatal("edit script's inserted+equal lines don't reconstruct b")
+	}
+}
+
+func TestChunkDiffCompareOnIdenticalInputIsAllEqual(t *testing.T) {
+	a := bigLines(500)
+	blocks := CompareWithOptions(a, a, CompareOptions{ChunkDiff: true})
+	for _, block := range blocks {
+		if block.Op != Equal {
+			t.Fatalf("identical input produced a non-Equal block: %+v", block)
+		}
+	}
+}
+
+func TestChunkDiffCompareHandlesEmptyInputs(t *testing.T) {
+	a := bigLines(50)
+	blocks := CompareWithOptions(nil, a, CompareOptions{ChunkDiff: true})
+	_, to := applyBlocks(blocks)
+	if !reflect.DeepEqual(to, a) {
+		t.Fatal("diffing empty against a reconstructs the wrong result")
+	}
+
+	blocks = CompareWithOptions(a, nil, CompareOptions{ChunkDiff: true})
+	from, _ := applyBlocks(blocks)
+	if !reflect.DeepEqual(from, a) {
+		t.Fatal("diffing a against empty reconstructs the wrong result")
+	}
+}
+
+func TestChunkDiffCompareSmallInputMatchesCompare(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"b", "d", "e"}
+
+	got := CompareWithOptions(a, b, CompareOptions{ChunkDiff: true})
+	from, to := applyBlocks(got)
+	if !reflect.DeepEqual(from, a) || !reflect.DeepEqual(to, b) {
+		t.Fatalf("small-input edit script didn't reconstruct a/b: from=%v to=%v", from, to)
+	}
+}
+
+func BenchmarkCompareChunkDiffVsPlain(b *testing.B) {
+	a := bigLines(5000)
+	c := bigLines(5000)
+	c[4999] = "changed"
+
+	b.Run("Plain", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Compare(a, c)
+		}
+	})
+	b.Run("ChunkDiff", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			CompareWithOptions(a, c, CompareOptions{ChunkDiff: true})
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package diff
+
+import "os"
+
+// ShouldColor reports whether diff output should be colored by default:
+// true unless NO_COLOR is set or stdout isn't a terminal. Callers that
+// always want (or never want) color can bypass this and pass their own
+// boolean to WriteUnified instead.
+func ShouldColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return (info.Mode() & os.ModeCharDevice) != 0
+}
+
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorReset = "\x1b[0m"
+)
@@ -0,0 +1,111 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// CompactOp is a byte-efficient, run-length-encoded counterpart to a
+// DiffBlock: its Op and how many elements it covers, plus (for Insert)
+// the inserted elements themselves. Equal and Delete blocks carry no
+// elements, since EncodeDiff/DecodeDiff reconstruct them from a shared
+// source sequence instead of resending it.
+type CompactOp struct {
+	Op       Op
+	Len      int
+	Inserted []string
+}
+
+// CompactOps reduces blocks to their CompactOp form.
+func CompactOps(blocks []DiffBlock) []CompactOp {
+	ops := make([]CompactOp, len(blocks))
+	for i, blk := range blocks {
+		op := CompactOp{Op: blk.Op, Len: len(blk.Lines)}
+		if blk.Op == Insert {
+			op.Inserted = blk.Lines
+		}
+		ops[i] = op
+	}
+	return ops
+}
+
+// EncodeDiff serializes blocks into a compact varint-based wire format:
+// for each block, a one-byte op kind, a varint length, and — for
+// inserted blocks only — the inserted lines themselves (each as a
+// varint byte length followed by its bytes). Equal and Delete content
+// isn't included, since DecodeDiff reconstructs it from the shared
+// source sequence a. This is the wire format for sending edits between
+// a server and an editor client without resending unchanged text.
+func EncodeDiff(blocks []DiffBlock) []byte {
+	var buf bytes.Buffer
+	varint := make([]byte, binary.MaxVarintLen64)
+
+	for _, op := range CompactOps(blocks) {
+		buf.WriteByte(byte(op.Op))
+		n := binary.PutUvarint(varint, uint64(op.Len))
+		buf.Write(varint[:n])
+
+		if op.Op == Insert {
+			for _, line := range op.Inserted {
+				n = binary.PutUvarint(varint, uint64(len(line)))
+				buf.Write(varint[:n])
+				buf.WriteString(line)
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+// DecodeDiff parses data (as produced by EncodeDiff) back into
+// DiffBlocks, pulling Equal/Delete content from a in order. a must be
+// the same source sequence the blocks were originally computed
+// against, or the result is nonsense.
+func DecodeDiff(data []byte, a []string) ([]DiffBlock, error) {
+	r := bytes.NewReader(data)
+	var blocks []DiffBlock
+	pos := 0
+
+	for r.Len() > 0 {
+		opByte, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("decoding diff: reading op: %w", err)
+		}
+		op := Op(opByte)
+
+		length64, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("decoding diff: reading length: %w", err)
+		}
+		length := int(length64)
+
+		var lines []string
+		switch op {
+		case Insert:
+			lines = make([]string, length)
+			for i := 0; i < length; i++ {
+				lineLen, err := binary.ReadUvarint(r)
+				if err != nil {
+					return nil, fmt.Errorf("decoding diff: reading insert length: %w", err)
+				}
+				raw := make([]byte, lineLen)
+				if _, err := io.ReadFull(r, raw); err != nil {
+					return nil, fmt.Errorf("decoding diff: reading insert bytes: %w", err)
+				}
+				lines[i] = string(raw)
+			}
+		case Equal, Delete:
+			if pos+length > len(a) {
+				return nil, fmt.Errorf("decoding diff: source sequence too short for a %s block of length %d", op, length)
+			}
+			lines = a[pos : pos+length]
+			pos += length
+		default:
+			return nil, fmt.Errorf("decoding diff: unknown op byte %d", opByte)
+		}
+
+		blocks = append(blocks, DiffBlock{Op: op, Lines: lines})
+	}
+	return blocks, nil
+}
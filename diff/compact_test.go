@@ -0,0 +1,65 @@
+package diff
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestApplyReproducesB(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"b", "d", "e"}
+	blocks := Compare(a, b)
+	if got := Apply(blocks); !EqualLines(got, b) {
+		t.Fatalf("Apply(Compare(a, b)) = %v, want %v", got, b)
+	}
+}
+
+func TestEncodeDecodeDiffRoundTrips(t *testing.T) {
+	a := []string{"one", "two", "three", "four"}
+	b := []string{"zero", "two", "three", "five"}
+	blocks := Compare(a, b)
+
+	data := EncodeDiff(blocks)
+	decoded, err := DecodeDiff(data, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, blocks) {
+		t.Fatalf("DecodeDiff(EncodeDiff(blocks)) = %v, want %v", decoded, blocks)
+	}
+	if got := Apply(decoded); !EqualLines(got, b) {
+		t.Fatalf("Apply(decoded) = %v, want %v", got, b)
+	}
+}
+
+func TestEncodeDecodeDiffRandomInputs(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	alphabet := []string{"a", "b", "c", "d", "e"}
+
+	randLines := func(n int) []string {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return out
+	}
+
+	for trial := 0; trial < 200; trial++ {
+		a := randLines(rng.Intn(12))
+		b := randLines(rng.Intn(12))
+		blocks := Compare(a, b)
+
+		data := EncodeDiff(blocks)
+		decoded, err := DecodeDiff(data, a)
+		if err != nil {
+			t.Fatalf("trial %d: DecodeDiff: %v", trial, err)
+		}
+		if !reflect.DeepEqual(decoded, blocks) {
+			t.Fatalf("trial %d: DecodeDiff(EncodeDiff(blocks)) = %v, want %v", trial, decoded, blocks)
+		}
+		if got := Apply(decoded); !EqualLines(got, b) {
+			t.Fatalf("trial %d: Apply(decoded) = %v, want %v", trial, got, b)
+		}
+	}
+}
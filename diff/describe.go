@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String renders block as "op[len]", e.g. "insert[3]". Use
+// DescribeBlock for a version that also previews the block's content.
+func (b DiffBlock) String() string {
+	return fmt.Sprintf("%s[%d]", b.Op, len(b.Lines))
+}
+
+// maxDescribePreview caps how much of a single line DescribeBlock shows,
+// so one very long line can't blow out a log line.
+const maxDescribePreview = 40
+
+// DescribeBlock renders block as "op[len]" followed by a short preview
+// of its content, rendered through render (e.g. strconv.Quote, or a
+// caller's own pretty-printer for non-string elements projected to
+// lines). Blocks of one or two lines show every line; longer blocks
+// show only the first and last, and any line longer than
+// maxDescribePreview is truncated with "...".
+func DescribeBlock(block DiffBlock, render func(string) string) string {
+	if render == nil {
+		render = func(s string) string { return s }
+	}
+
+	n := len(block.Lines)
+	if n == 0 {
+		return block.String()
+	}
+
+	preview := func(i int) string { return truncate(render(block.Lines[i])) }
+
+	if n <= 2 {
+		parts := make([]string, n)
+		for i := range block.Lines {
+			parts[i] = preview(i)
+		}
+		return fmt.Sprintf("%s %s", block.String(), strings.Join(parts, ", "))
+	}
+	return fmt.Sprintf("%s %s ... %s", block.String(), preview(0), preview(n-1))
+}
+
+// truncate shortens s to at most maxDescribePreview characters, adding
+// "..." when it's cut short.
+func truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxDescribePreview {
+		return s
+	}
+	return string(runes[:maxDescribePreview]) + "..."
+}
@@ -0,0 +1,46 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDiffBlockString(t *testing.T) {
+	block := DiffBlock{Op: Insert, Lines: []string{"a", "b", "c"}}
+	if got := block.String(); got != "insert[3]" {
+		t.Fatalf("String() = %q, want %q", got, "insert[3]")
+	}
+}
+
+func TestDescribeBlockEmpty(t *testing.T) {
+	block := DiffBlock{Op: Equal}
+	if got := DescribeBlock(block, nil); got != "equal[0]" {
+		t.Fatalf("DescribeBlock(empty) = %q, want %q", got, "equal[0]")
+	}
+}
+
+func TestDescribeBlockShowsEveryLineWhenShort(t *testing.T) {
+	block := DiffBlock{Op: Delete, Lines: []string{"x", "y"}}
+	got := DescribeBlock(block, strconv.Quote)
+	if got != `delete[2] "x", "y"` {
+		t.Fatalf("DescribeBlock = %q", got)
+	}
+}
+
+func TestDescribeBlockShowsFirstAndLastWhenLong(t *testing.T) {
+	block := DiffBlock{Op: Insert, Lines: []string{"a", "b", "c", "d"}}
+	got := DescribeBlock(block, nil)
+	if got != "insert[4] a ... d" {
+		t.Fatalf("DescribeBlock = %q", got)
+	}
+}
+
+func TestDescribeBlockTruncatesLongLines(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	block := DiffBlock{Op: Equal, Lines: []string{long}}
+	got := DescribeBlock(block, nil)
+	if !strings.Contains(got, "...") || len(got) > maxDescribePreview+len("equal[1] ")+3 {
+		t.Fatalf("DescribeBlock did not truncate: %q", got)
+	}
+}
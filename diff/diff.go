@@ -0,0 +1,292 @@
+// Package diff computes line-level differences between two sequences of
+// strings using the Myers algorithm with the linear-space "middle snake"
+// refinement, so it stays memory-efficient on large inputs.
+package diff
+
+// Op identifies the kind of change a DiffBlock represents.
+type Op int
+
+const (
+	// Equal marks a run of lines present, unchanged, in both sequences.
+	Equal Op = iota
+	// Delete marks a run of lines present only in the first sequence.
+	Delete
+	// Insert marks a run of lines present only in the second sequence.
+	Insert
+)
+
+// DiffBlock is a maximal run of consecutive lines sharing the same Op.
+type DiffBlock struct {
+	Op    Op
+	Lines []string
+}
+
+// editOp models a single line-level edit produced while walking
+// the edit graph, before adjacent edits of the same kind are coalesced
+// into DiffBlocks.
+type editOp struct {
+	op   Op
+	line string
+}
+
+// Workspace holds scratch buffers reused across Compare/LCS calls so that
+// diffing many small inputs in a loop doesn't repeatedly allocate the
+// forward/backward D-path arrays. The zero value is ready to use.
+type Workspace struct {
+	vf []int
+	vb []int
+}
+
+// buffers returns the forward (vf) and backward (vb) D-path arrays sized
+// for the given maxD, growing and reusing the underlying storage rather
+// than reallocating when it's already large enough.
+func (ws *Workspace) buffers(maxD int) (vf, vb []int) {
+	size := 2*maxD + 3
+	if cap(ws.vf) < size {
+		ws.vf = make([]int, size)
+	} else {
+		ws.vf = ws.vf[:size]
+		for i := range ws.vf {
+			ws.vf[i] = 0
+		}
+	}
+	if cap(ws.vb) < size {
+		ws.vb = make([]int, size)
+	} else {
+		ws.vb = ws.vb[:size]
+		for i := range ws.vb {
+			ws.vb[i] = 0
+		}
+	}
+	return ws.vf, ws.vb
+}
+
+// Compare returns the diff blocks turning a into b, allocating a fresh
+// Workspace for the computation.
+func Compare(a, b []string) []DiffBlock {
+	return CompareWith(a, b, &Workspace{})
+}
+
+// CompareWith is like Compare but reuses ws's scratch buffers instead of
+// allocating new ones, cutting allocations when diffing many inputs in a
+// loop. A nil ws is treated as a fresh Workspace. Results are identical
+// to Compare for the same inputs.
+func CompareWith(a, b []string, ws *Workspace) []DiffBlock {
+	if ws == nil {
+		ws = &Workspace{}
+	}
+	ops := computeLCS(a, b, ws)
+	return buildBlocks(ops)
+}
+
+// LCS returns the longest common subsequence of lines shared by a and b,
+// in order.
+func LCS(a, b []string) []string {
+	ops := computeLCS(a, b, &Workspace{})
+	out := make([]string, 0, len(ops))
+	for _, op := range ops {
+		if op.op == Equal {
+			out = append(out, op.line)
+		}
+	}
+	return out
+}
+
+// computeLCS walks the edit graph for a and b, recursing on the pieces on
+// either side of each middle snake found by diffFindMidSnakes, and
+// returns the flattened, in-order list of edits.
+func computeLCS(a, b []string, ws *Workspace) []editOp {
+	n, m := len(a), len(b)
+	switch {
+	case n == 0 && m == 0:
+		return nil
+	case n == 0:
+		ops := make([]editOp, m)
+		for i, line := range b {
+			ops[i] = editOp{op: Insert, line: line}
+		}
+		return ops
+	case m == 0:
+		ops := make([]editOp, n)
+		for i, line := range a {
+			ops[i] = editOp{op: Delete, line: line}
+		}
+		return ops
+	}
+
+	// Peel off any common prefix/suffix before searching for a middle
+	// snake. Besides shrinking the search, this is what guarantees
+	// progress: without it, a sequence that's a prefix/suffix extension
+	// of the other can make diffFindMidSnakes land its middle snake
+	// exactly on the subproblem's own boundary (e.g.
+	// Compare([]string{"x"}, []string{"x","x"})), which would otherwise
+	// recurse on the exact same (a, b) forever.
+	start := 0
+	for start < n && start < m && a[start] == b[start] {
+		start++
+	}
+	end := 0
+	for end < n-start && end < m-start && a[n-1-end] == b[m-1-end] {
+		end++
+	}
+	if start > 0 || end > 0 {
+		var ops []editOp
+		for _, line := range a[:start] {
+			ops = append(ops, editOp{op: Equal, line: line})
+		}
+		ops = append(ops, computeLCS(a[start:n-end], b[start:m-end], ws)...)
+		for _, line := range a[n-end:] {
+			ops = append(ops, editOp{op: Equal, line: line})
+		}
+		return ops
+	}
+
+	x1, y1, x2, y2 := diffFindMidSnakes(a, b, ws)
+	if (x1 == n && y1 == m) || (x2 == 0 && y2 == 0) {
+		// Invariant: a middle snake must strictly shrink both halves of
+		// the subproblem, or recursing on either one never terminates.
+		// This should be unreachable now that common prefixes/suffixes
+		// are peeled off above, but fall back to a guaranteed-
+		// terminating (if O(n*m)) direct LCS rather than risk a stack
+		// overflow if some input still trips it.
+		return bruteForceLCS(a, b)
+	}
+
+	var ops []editOp
+	ops = append(ops, computeLCS(a[:x1], b[:y1], ws)...)
+	for i := x1; i < x2; i++ {
+		ops = append(ops, editOp{op: Equal, line: a[i]})
+	}
+	ops = append(ops, computeLCS(a[x2:], b[y2:], ws)...)
+	return ops
+}
+
+// bruteForceLCS computes edits for a and b using a direct O(n*m)
+// dynamic-programming LCS. It exists solely as the guaranteed-
+// terminating fallback computeLCS reaches for if diffFindMidSnakes ever
+// returns a middle snake that doesn't shrink the subproblem.
+func bruteForceLCS(a, b []string) []editOp {
+	n, m := len(a), len(b)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				dp[i][j] = dp[i+1][j+1] + 1
+			case dp[i+1][j] >= dp[i][j+1]:
+				dp[i][j] = dp[i+1][j]
+			default:
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	var ops []editOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, editOp{op: Equal, line: a[i]})
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			ops = append(ops, editOp{op: Delete, line: a[i]})
+			i++
+		default:
+			ops = append(ops, editOp{op: Insert, line: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, editOp{op: Delete, line: a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, editOp{op: Insert, line: b[j]})
+	}
+	return ops
+}
+
+// diffFindMidSnakes locates a middle snake for a and b: a maximal run of
+// matching lines that some shortest edit script passes through, found by
+// growing forward and backward D-paths until they overlap. It returns
+// the snake's bounds as [x1,x2) in a and [y1,y2) in b.
+func diffFindMidSnakes(a, b []string, ws *Workspace) (x1, y1, x2, y2 int) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	vf, vb := ws.buffers(maxD)
+	off := maxD + 1
+	delta := n - m
+
+	vf[off+1] = 0
+	vb[off+1] = 0
+
+	for d := 0; d <= maxD; d++ {
+		// Forward search: extend D-paths from (0,0) towards (n,m).
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			vf[off+k] = x
+
+			if delta%2 != 0 {
+				c := delta - k
+				if c >= -(d-1) && c <= d-1 && x+vb[off+c] >= n {
+					return sx, sy, x, y
+				}
+			}
+		}
+
+		// Backward search: extend D-paths from (n,m) towards (0,0).
+		for c := -d; c <= d; c += 2 {
+			var u int
+			if c == -d || (c != d && vb[off+c-1] < vb[off+c+1]) {
+				u = vb[off+c+1]
+			} else {
+				u = vb[off+c-1] + 1
+			}
+			v := u - c
+			su, sv := u, v
+			for u < n && v < m && a[n-u-1] == b[m-v-1] {
+				u++
+				v++
+			}
+			vb[off+c] = u
+
+			if delta%2 == 0 {
+				k := delta - c
+				if k >= -d && k <= d && u+vf[off+k] >= n {
+					return n - u, m - v, n - su, m - sv
+				}
+			}
+		}
+	}
+
+	// Unreachable: the loop above always finds an overlap within maxD
+	// steps, per Myers' proof that the edit distance is at most n+m.
+	return 0, 0, 0, 0
+}
+
+// buildBlocks coalesces consecutive edits of the same kind into blocks.
+func buildBlocks(ops []editOp) []DiffBlock {
+	var blocks []DiffBlock
+	for _, op := range ops {
+		if n := len(blocks); n > 0 && blocks[n-1].Op == op.op {
+			blocks[n-1].Lines = append(blocks[n-1].Lines, op.line)
+			continue
+		}
+		blocks = append(blocks, DiffBlock{Op: op.op, Lines: []string{op.line}})
+	}
+	return blocks
+}
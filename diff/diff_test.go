@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func linesOf(blocks []DiffBlock) (a, b []string) {
+	for _, blk := range blocks {
+		switch blk.Op {
+		case Equal:
+			a = append(a, blk.Lines...)
+			b = append(b, blk.Lines...)
+		case Delete:
+			a = append(a, blk.Lines...)
+		case Insert:
+			b = append(b, blk.Lines...)
+		}
+	}
+	return a, b
+}
+
+func TestCompareReconstructsInputs(t *testing.T) {
+	cases := [][2][]string{
+		{nil, nil},
+		{[]string{"a"}, nil},
+		{nil, []string{"a"}},
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		{[]string{"a", "b", "c"}, []string{"a", "x", "c"}},
+		{[]string{"a", "b", "c", "d"}, []string{"b", "d", "e"}},
+		// One sequence a strict prefix/suffix extension of the other
+		// used to make diffFindMidSnakes return a middle snake
+		// identical to the original subproblem, recursing forever.
+		{[]string{"x"}, []string{"x", "x"}},
+		{[]string{"x", "x"}, []string{"x"}},
+		{[]string{"x"}, []string{"x", "x", "x"}},
+	}
+	for _, tc := range cases {
+		blocks := Compare(tc[0], tc[1])
+		a, b := linesOf(blocks)
+		if !reflect.DeepEqual(a, tc[0]) || !reflect.DeepEqual(b, tc[1]) {
+			t.Fatalf("Compare(%v, %v) round-trips to (%v, %v)", tc[0], tc[1], a, b)
+		}
+	}
+}
+
+func TestCompareWithMatchesCompare(t *testing.T) {
+	a := []string{"one", "two", "three", "four"}
+	b := []string{"zero", "two", "three", "five"}
+
+	want := Compare(a, b)
+
+	ws := &Workspace{}
+	for i := 0; i < 3; i++ {
+		got := CompareWith(a, b, ws)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("CompareWith with reused workspace = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLCS(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"b", "d", "e"}
+	got := LCS(a, b)
+	want := []string{"b", "d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("LCS = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkCompareAlloc(b *testing.B) {
+	lines := func(n int, suffix string) []string {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = "line " + suffix
+		}
+		return out
+	}
+	a := lines(20, "a")
+	c := lines(20, "b")
+
+	b.Run("NoWorkspace", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Compare(a, c)
+		}
+	})
+	b.Run("PooledWorkspace", func(b *testing.B) {
+		ws := &Workspace{}
+		for i := 0; i < b.N; i++ {
+			CompareWith(a, c, ws)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package diff
+
+// EqualLines reports whether a and b hold the same lines in the same order,
+// without computing a diff. Callers that only need a yes/no answer —
+// the common case when checking whether a test's actual output matches
+// its expected output — should use this instead of Compare(a,
+// b), which does the (much more expensive) work of locating every
+// difference even when there turns out to be none.
+func EqualLines(a, b []string) bool {
+	return EqualFunc(a, b, func(x, y string) bool { return x == y })
+}
+
+// EqualFunc is like EqualLines but compares corresponding elements with eq
+// instead of ==, e.g. to ignore trailing whitespace per line.
+func EqualFunc(a, b []string, eq func(a, b string) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// NoChanges reports whether blocks contains no insertions or deletions,
+// i.e. the two compared inputs were identical. It's a cheap alternative
+// to walking blocks by hand just to answer that one question.
+func NoChanges(blocks []DiffBlock) bool {
+	for _, blk := range blocks {
+		if blk.Op != Equal {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualLines(t *testing.T) {
+	if !EqualLines([]string{"a", "b"}, []string{"a", "b"}) {
+		t.Fatal("EqualLines = false for identical inputs, want true")
+	}
+	if EqualLines([]string{"a", "b"}, []string{"a", "b", "c"}) {
+		t.Fatal("EqualLines = true for slices of different length, want false")
+	}
+	if EqualLines([]string{"a", "b"}, []string{"a", "x"}) {
+		t.Fatal("EqualLines = true when an element differs, want false")
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	caseInsensitive := func(a, b string) bool { return strings.EqualFold(a, b) }
+	if !EqualFunc([]string{"Hello"}, []string{"hello"}, caseInsensitive) {
+		t.Fatal("EqualFunc = false for case-insensitive match, want true")
+	}
+	if EqualFunc([]string{"Hello"}, []string{"world"}, caseInsensitive) {
+		t.Fatal("EqualFunc = true for non-matching strings, want false")
+	}
+}
+
+func BenchmarkEqualVsCompare(b *testing.B) {
+	a := make([]string, 1000)
+	for i := range a {
+		a[i] = "the quick brown fox jumps over the lazy dog"
+	}
+	same := append([]string{}, a...)
+
+	b.Run("EqualLines", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			EqualLines(a, same)
+		}
+	})
+	b.Run("Compare", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Compare(a, same)
+		}
+	})
+}
+
+func TestNoChanges(t *testing.T) {
+	same := Compare([]string{"a", "b"}, []string{"a", "b"})
+	if !NoChanges(same) {
+		t.Fatal("NoChanges = false for identical inputs, want true")
+	}
+
+	different := Compare([]string{"a", "b"}, []string{"a", "x"})
+	if NoChanges(different) {
+		t.Fatal("NoChanges = true when a single element differs, want false")
+	}
+}
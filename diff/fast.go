@@ -0,0 +1,19 @@
+package diff
+
+// CompareFast is Compare, named explicitly for callers choosing between
+// diffing strategies: it always uses the plain Myers algorithm and never
+// pays for CompareWithOptions' UniqueAnchors preprocessing. Pick this
+// over CompareWithOptions when you only need a correct minimal edit
+// script — e.g. to decide whether two outputs differ at all — not the
+// more readable alignment unique anchors can produce on repetitive
+// input.
+func CompareFast(a, b []string) []DiffBlock {
+	return Compare(a, b)
+}
+
+// LCSFast is LCS, named explicitly alongside CompareFast for callers
+// who want to be clear they're taking the plain, unranked longest
+// common subsequence rather than any alignment-quality preprocessing.
+func LCSFast(a, b []string) []string {
+	return LCS(a, b)
+}
@@ -0,0 +1,30 @@
+package diff
+
+import "testing"
+
+// repetitive builds input with lots of duplicate lines, the case where
+// UniqueAnchors preprocessing earns its cost.
+func repetitive(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = "line"
+	}
+	return out
+}
+
+func BenchmarkCompareFastVsAnchored(b *testing.B) {
+	a := repetitive(200)
+	c := repetitive(200)
+	c[100] = "unique"
+
+	b.Run("CompareFast", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			CompareFast(a, c)
+		}
+	})
+	b.Run("UniqueAnchors", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			CompareWithOptions(a, c, CompareOptions{UniqueAnchors: true})
+		}
+	})
+}
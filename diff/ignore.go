@@ -0,0 +1,157 @@
+package diff
+
+// CompareOptions configures a CompareWithOptions call.
+type CompareOptions struct {
+	// UniqueAnchors enables a patience-diff-style preprocessing pass:
+	// lines that occur exactly once in both a and b are used as fixed
+	// match points, and the (usually much smaller) gaps between
+	// consecutive anchors are diffed with the regular Myers algorithm.
+	// This avoids Myers misaligning long runs of repeated lines on
+	// structured output such as logs or generated code. Ignored when
+	// Ignore is set, since anchoredEdits has no ignore-aware path.
+	UniqueAnchors bool
+
+	// AdaptiveCandidates, combined with UniqueAnchors, bounds how many
+	// anchor candidates longestIncreasingByA considers — that search is
+	// O(n^2) in the candidate count, so an unbounded candidate set is
+	// wasteful on tiny diffs (too few candidates to matter) and
+	// expensive on huge ones. The budget is min(len(a), len(b)) clamped
+	// to [MinCandidates, MaxCandidates], and an over-budget candidate
+	// set is downsampled to evenly spaced candidates so the anchors
+	// still span the whole input. The default (false) considers every
+	// candidate, matching historical behavior.
+	AdaptiveCandidates bool
+	// MinCandidates and MaxCandidates bound the adaptive budget.
+	// Non-positive values fall back to defaultMinCandidates and
+	// defaultMaxCandidates respectively.
+	MinCandidates, MaxCandidates int
+
+	// Ignore, if non-nil, marks lines that should be skipped while
+	// aligning a and b: an ignorable line never forms part of the LCS
+	// and never forces a Delete/Insert decision on its own, so e.g.
+	// whitespace-only or comment lines interspersed among real changes
+	// don't throw off the alignment of the lines that matter. Ignored
+	// lines are still present in the result, at their original
+	// position — see CompareWithOptions for the placement rule.
+	Ignore func(line string) bool
+
+	// Weight, if non-nil, breaks ties among equal-length alignments in
+	// favor of the one matching more total weight, so e.g. significant
+	// tokens can be preferred over incidental ones when several
+	// alignments are otherwise equally good. The default (nil) weighs
+	// every line as 1, matching Compare's behavior. See weightedLCSOps
+	// for how weights influence tie-breaking.
+	Weight func(line string) int
+
+	// Parallel, if true and Ignore/Weight are both nil, computes the
+	// diff with computeLCSParallel instead of the sequential Compare,
+	// splitting large inputs' independent sub-problems across
+	// goroutines bounded by GOMAXPROCS. Results are identical to
+	// Compare; only large inputs benefit, since small ones pay a
+	// goroutine's overhead for no gain. Ignored when Ignore or Weight
+	// is set, since neither's extra bookkeeping has a parallel path.
+	Parallel bool
+
+	// ChunkDiff, if true and Ignore/Weight are both nil, pre-filters
+	// large unchanged regions with content-defined chunking before
+	// running the precise diff on what's left (see chunkDiffCompare).
+	// The result is always a correct edit script but, unlike Compare,
+	// not guaranteed minimal right at a chunk boundary. Takes
+	// precedence over Parallel when both are set, since ChunkDiff's
+	// own precise pass runs on whatever a Parallel run would have had
+	// to chew through anyway.
+	ChunkDiff bool
+}
+
+// CompareWithOptions is like Compare but lets the caller opt into
+// alternate diffing strategies via opts. When opts.Ignore is set, lines
+// it reports true for are removed before the LCS is computed over the
+// remaining lines, then spliced
+// back into the result at their original position. Since an ignored
+// line took no part in deciding which lines matched, it carries the Op
+// of the side it came from (Delete for one from a, Insert for one from
+// b) — it's not claimed to be equal to anything, just placed where it
+// originally was relative to the lines that were compared.
+func CompareWithOptions(a, b []string, opts CompareOptions) []DiffBlock {
+	if opts.Ignore == nil {
+		switch {
+		case opts.Weight != nil:
+			return weightedCompare(a, b, opts.Weight)
+		case opts.UniqueAnchors:
+			// Takes precedence over ChunkDiff/Parallel since it changes
+			// the alignment itself, not just the performance strategy
+			// used to reach the same one.
+			return buildBlocks(anchoredEdits(a, b, &Workspace{}, opts))
+		case opts.ChunkDiff:
+			return chunkDiffCompare(a, b)
+		case opts.Parallel:
+			return parallelCompare(a, b)
+		default:
+			return Compare(a, b)
+		}
+	}
+
+	var af, bf []string
+	var aIdx, bIdx []int
+	for i, line := range a {
+		if !opts.Ignore(line) {
+			af = append(af, line)
+			aIdx = append(aIdx, i)
+		}
+	}
+	for i, line := range b {
+		if !opts.Ignore(line) {
+			bf = append(bf, line)
+			bIdx = append(bIdx, i)
+		}
+	}
+
+	var filtered []editOp
+	if opts.Weight != nil {
+		filtered = weightedLCSOps(af, bf, opts.Weight)
+	} else {
+		filtered = computeLCS(af, bf, &Workspace{})
+	}
+
+	var edits []editOp
+	ai, bi := 0, 0
+	fi, fj := 0, 0
+
+	emitIgnoredA := func(upto int) {
+		for ai < upto {
+			edits = append(edits, editOp{op: Delete, line: a[ai]})
+			ai++
+		}
+	}
+	emitIgnoredB := func(upto int) {
+		for bi < upto {
+			edits = append(edits, editOp{op: Insert, line: b[bi]})
+			bi++
+		}
+	}
+
+	for _, op := range filtered {
+		switch op.op {
+		case Equal:
+			emitIgnoredA(aIdx[fi])
+			emitIgnoredB(bIdx[fj])
+			edits = append(edits, editOp{op: Equal, line: op.line})
+			ai, bi = aIdx[fi]+1, bIdx[fj]+1
+			fi, fj = fi+1, fj+1
+		case Delete:
+			emitIgnoredA(aIdx[fi])
+			edits = append(edits, editOp{op: Delete, line: op.line})
+			ai = aIdx[fi] + 1
+			fi++
+		case Insert:
+			emitIgnoredB(bIdx[fj])
+			edits = append(edits, editOp{op: Insert, line: op.line})
+			bi = bIdx[fj] + 1
+			fj++
+		}
+	}
+	emitIgnoredA(len(a))
+	emitIgnoredB(len(b))
+
+	return buildBlocks(edits)
+}
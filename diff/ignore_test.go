@@ -0,0 +1,65 @@
+package diff
+
+import "testing"
+
+func isBlank(line string) bool { return line == "" }
+
+func TestCompareWithOptionsNilIgnoreMatchesCompare(t *testing.T) {
+	a := []string{"x", "y"}
+	b := []string{"x", "z"}
+	got := CompareWithOptions(a, b, CompareOptions{})
+	want := Compare(a, b)
+	if len(got) != len(want) {
+		t.Fatalf("CompareWithOptions with nil Ignore = %v, want %v", got, want)
+	}
+}
+
+func TestCompareWithOptionsIgnoresBlankLinesDuringAlignment(t *testing.T) {
+	// Without ignoring blanks, the extra blank line in b would shift
+	// the alignment; with it ignored, "same" still matches as Equal.
+	a := []string{"same", "changed"}
+	b := []string{"same", "", "changed"}
+
+	blocks := CompareWithOptions(a, b, CompareOptions{Ignore: isBlank})
+
+	var sawEqualSame bool
+	for _, blk := range blocks {
+		if blk.Op == Equal {
+			for _, l := range blk.Lines {
+				if l == "same" {
+					sawEqualSame = true
+				}
+			}
+		}
+	}
+	if !sawEqualSame {
+		t.Fatalf("expected \"same\" to align as Equal, got %v", blocks)
+	}
+
+	// The blank line itself must still be present somewhere.
+	var sawBlank bool
+	for _, blk := range blocks {
+		for _, l := range blk.Lines {
+			if l == "" {
+				sawBlank = true
+			}
+		}
+	}
+	if !sawBlank {
+		t.Fatalf("expected the ignored blank line to still appear in the output, got %v", blocks)
+	}
+}
+
+func TestCompareWithOptionsPreservesIgnoredLineCount(t *testing.T) {
+	a := []string{"", "a", ""}
+	b := []string{"a"}
+	blocks := CompareWithOptions(a, b, CompareOptions{Ignore: isBlank})
+
+	var total int
+	for _, blk := range blocks {
+		total += len(blk.Lines)
+	}
+	if total != len(a)+len(b)-1 { // "a" is shared between a and b, counted once as Equal
+		t.Fatalf("total lines in blocks = %d, want %d", total, len(a)+len(b)-1)
+	}
+}
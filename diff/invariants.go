@@ -0,0 +1,61 @@
+package diff
+
+import "fmt"
+
+// CheckInvariants verifies that blocks is a well-formed diff between a
+// and b: consecutive blocks never share the same Op (they would have
+// been coalesced into one), every block's lines match a and/or b at
+// their current offsets, and walking all blocks consumes exactly a and
+// exactly b. It returns a descriptive error on the first violation
+// found instead of failing a *testing.T, so callers outside this
+// package's own tests (e.g. a `go test -fuzz` target over Compare) can
+// validate results too.
+func CheckInvariants(a, b []string, blocks []DiffBlock) error {
+	lastOp := Op(-1)
+	ai, bi := 0, 0
+
+	for i, block := range blocks {
+		if block.Op == lastOp {
+			return fmt.Errorf("block %d: consecutive %s blocks should have been coalesced", i, block.Op)
+		}
+		lastOp = block.Op
+
+		switch block.Op {
+		case Equal:
+			for j, line := range block.Lines {
+				if ai+j >= len(a) || a[ai+j] != line {
+					return fmt.Errorf("block %d: equal line %d does not match a[%d]", i, j, ai+j)
+				}
+				if bi+j >= len(b) || b[bi+j] != line {
+					return fmt.Errorf("block %d: equal line %d does not match b[%d]", i, j, bi+j)
+				}
+			}
+			ai += len(block.Lines)
+			bi += len(block.Lines)
+		case Delete:
+			for j, line := range block.Lines {
+				if ai+j >= len(a) || a[ai+j] != line {
+					return fmt.Errorf("block %d: delete line %d does not match a[%d]", i, j, ai+j)
+				}
+			}
+			ai += len(block.Lines)
+		case Insert:
+			for j, line := range block.Lines {
+				if bi+j >= len(b) || b[bi+j] != line {
+					return fmt.Errorf("block %d: insert line %d does not match b[%d]", i, j, bi+j)
+				}
+			}
+			bi += len(block.Lines)
+		default:
+			return fmt.Errorf("block %d: unknown op %v", i, block.Op)
+		}
+	}
+
+	if ai != len(a) {
+		return fmt.Errorf("blocks consumed %d of %d lines of a", ai, len(a))
+	}
+	if bi != len(b) {
+		return fmt.Errorf("blocks consumed %d of %d lines of b", bi, len(b))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package diff
+
+import "testing"
+
+func TestCheckInvariantsPassesForRealDiffs(t *testing.T) {
+	cases := [][2][]string{
+		{{"a", "b", "c"}, {"a", "x", "c"}},
+		{{}, {}},
+		{{"a"}, {}},
+		{{}, {"a"}},
+		{{"a", "b"}, {"a", "b"}},
+	}
+	for _, c := range cases {
+		blocks := Compare(c[0], c[1])
+		if err := CheckInvariants(c[0], c[1], blocks); err != nil {
+			t.Errorf("Compare(%v, %v) violated an invariant: %v", c[0], c[1], err)
+		}
+	}
+}
+
+func TestCheckInvariantsCatchesConsecutiveSameOpBlocks(t *testing.T) {
+	blocks := []DiffBlock{
+		{Op: Equal, Lines: []string{"a"}},
+		{Op: Equal, Lines: []string{"b"}},
+	}
+	if err := CheckInvariants([]string{"a", "b"}, []string{"a", "b"}, blocks); err == nil {
+		t.Fatal("expected an error for consecutive Equal blocks")
+	}
+}
+
+func TestCheckInvariantsCatchesMismatchedContent(t *testing.T) {
+	blocks := []DiffBlock{{Op: Equal, Lines: []string{"a"}}}
+	if err := CheckInvariants([]string{"x"}, []string{"a"}, blocks); err == nil {
+		t.Fatal("expected an error for an Equal block that doesn't match a")
+	}
+}
+
+func TestCheckInvariantsCatchesIncompleteCoverage(t *testing.T) {
+	blocks := []DiffBlock{{Op: Equal, Lines: []string{"a"}}}
+	if err := CheckInvariants([]string{"a", "b"}, []string{"a"}, blocks); err == nil {
+		t.Fatal("expected an error when blocks don't consume all of a")
+	}
+}
+
+func FuzzCompareInvariants(f *testing.F) {
+	f.Add("hello world", "hallo word")
+	f.Add("", "")
+	f.Add("abc", "")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		ra, rb := runeLines(a), runeLines(b)
+		if err := CheckInvariants(ra, rb, Compare(ra, rb)); err != nil {
+			t.Fatalf("Compare(%q, %q) violated an invariant: %v", a, b, err)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package diff
+
+import "encoding/json"
+
+// String returns the op's lowercase name, used for both debugging and
+// JSON serialization.
+func (op Op) String() string {
+	switch op {
+	case Equal:
+		return "equal"
+	case Delete:
+		return "delete"
+	case Insert:
+		return "insert"
+	default:
+		return "unknown"
+	}
+}
+
+// jsonBlock is DiffBlock's JSON representation, with Op spelled out as a
+// name instead of its underlying int.
+type jsonBlock struct {
+	Op    string   `json:"op"`
+	Lines []string `json:"lines"`
+}
+
+// MarshalJSON renders the block's Op as its name rather than its raw
+// int value, so consumers outside this package don't need to know the
+// ordering of the Op constants.
+func (b DiffBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonBlock{Op: b.Op.String(), Lines: b.Lines})
+}
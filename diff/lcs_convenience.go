@@ -0,0 +1,38 @@
+package diff
+
+import "strings"
+
+// LCSRunes returns the longest common subsequence of runes shared by a
+// and b, as a string. It's the rune-based counterpart to LCS, built on
+// top of it by treating each rune as a single-rune "line".
+func LCSRunes(a, b string) string {
+	matched := LCS(runeLines(a), runeLines(b))
+	return strings.Join(matched, "")
+}
+
+// EditDistance returns the indel distance between a and b: the minimum
+// number of single-element deletions and insertions needed to turn a
+// into b. Unlike Levenshtein distance, substitutions aren't a single
+// step here — changing one element costs a delete plus an insert — so
+// this is always len(a) + len(b) - 2*len(LCS(a, b)), reusing the same
+// Myers machinery as Compare.
+func EditDistance(a, b []string) int {
+	return len(a) + len(b) - 2*len(LCS(a, b))
+}
+
+// EditDistanceStrings is the rune-based counterpart to EditDistance,
+// built on LCSRunes the way LCSRunes is built on LCS.
+func EditDistanceStrings(a, b string) int {
+	return len([]rune(a)) + len([]rune(b)) - 2*len([]rune(LCSRunes(a, b)))
+}
+
+// runeLines splits s into a []string of its individual runes, so the
+// line-oriented LCS can be reused for rune sequences.
+func runeLines(s string) []string {
+	runes := []rune(s)
+	out := make([]string, len(runes))
+	for i, r := range runes {
+		out[i] = string(r)
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package diff
+
+import "testing"
+
+func TestLCSRunes(t *testing.T) {
+	cases := []struct {
+		a, b, want string
+	}{
+		{"", "", ""},
+		{"abc", "", ""},
+		{"", "abc", ""},
+		{"abcd", "bdef", "bd"},
+		{"human", "chimpanzee", "hman"},
+	}
+	for _, tc := range cases {
+		if got := LCSRunes(tc.a, tc.b); got != tc.want {
+			t.Fatalf("LCSRunes(%q, %q) = %q, want %q", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b []string
+		want int
+	}{
+		{nil, nil, 0},
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, 0},
+		{[]string{"a", "b", "c"}, nil, 3},
+		{[]string{"a", "b", "c", "d"}, []string{"b", "d", "e"}, 3},
+	}
+	for _, tc := range cases {
+		if got := EditDistance(tc.a, tc.b); got != tc.want {
+			t.Fatalf("EditDistance(%v, %v) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestEditDistanceStrings(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"kitten", "kitten", 0},
+		{"abcd", "bdef", 4},
+		{"human", "chimpanzee", 7},
+	}
+	for _, tc := range cases {
+		if got := EditDistanceStrings(tc.a, tc.b); got != tc.want {
+			t.Fatalf("EditDistanceStrings(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package diff
+
+import "strings"
+
+// hunk is a base-relative replacement: base[start:end) is replaced by
+// lines. A pure deletion has lines == nil; a pure insertion has
+// start == end.
+type hunk struct {
+	start, end int
+	lines      []string
+}
+
+// hunksFromBlocks collapses a Compare(base, other) result into hunks:
+// each run of adjacent Delete/Insert blocks (a modified region, with no
+// Equal block between them) becomes one hunk, so a changed line doesn't
+// look like an unrelated delete-then-insert pair to the diff3 merge
+// below.
+func hunksFromBlocks(blocks []DiffBlock) []hunk {
+	var hunks []hunk
+	baseIdx := 0
+	i := 0
+	for i < len(blocks) {
+		blk := blocks[i]
+		if blk.Op == Equal {
+			baseIdx += len(blk.Lines)
+			i++
+			continue
+		}
+
+		start := baseIdx
+		end := baseIdx
+		var lines []string
+		for i < len(blocks) && blocks[i].Op != Equal {
+			if blocks[i].Op == Delete {
+				end += len(blocks[i].Lines)
+			} else {
+				lines = append(lines, blocks[i].Lines...)
+			}
+			i++
+		}
+		hunks = append(hunks, hunk{start: start, end: end, lines: lines})
+		baseIdx = end
+	}
+	return hunks
+}
+
+// Conflict is a region where a and b each changed the same part of base
+// differently. Index and Len locate the conflicting content within the
+// merged slice Merge3 returns, so RenderConflicts can find and replace
+// it with diff3-style markers.
+type Conflict struct {
+	Index, Len int
+	Base, A, B []string
+}
+
+// Merge3 performs a diff3-style three-way merge: changes a and b each
+// made relative to base are combined, applying whichever side changed a
+// region when only one did, and keeping a's version (while recording a
+// Conflict) when both changed the same region differently. Identical
+// changes on both sides are not conflicts.
+func Merge3(base, a, b []string) (merged []string, conflicts []Conflict) {
+	hunksA := hunksFromBlocks(Compare(base, a))
+	hunksB := hunksFromBlocks(Compare(base, b))
+
+	var out []string
+	pos := 0
+	ia, ib := 0, 0
+
+	emitBaseThrough := func(upto int) {
+		out = append(out, base[pos:upto]...)
+		pos = upto
+	}
+
+	for ia < len(hunksA) || ib < len(hunksB) {
+		var ha, hb *hunk
+		if ia < len(hunksA) {
+			ha = &hunksA[ia]
+		}
+		if ib < len(hunksB) {
+			hb = &hunksB[ib]
+		}
+
+		switch {
+		case ha != nil && (hb == nil || ha.end <= hb.start):
+			emitBaseThrough(ha.start)
+			out = append(out, ha.lines...)
+			pos = ha.end
+			ia++
+		case hb != nil && (ha == nil || hb.end <= ha.start):
+			emitBaseThrough(hb.start)
+			out = append(out, hb.lines...)
+			pos = hb.end
+			ib++
+		default:
+			start := min(ha.start, hb.start)
+			end := max(ha.end, hb.end)
+			emitBaseThrough(start)
+			if ha.start == hb.start && ha.end == hb.end && EqualLines(ha.lines, hb.lines) {
+				out = append(out, ha.lines...)
+			} else {
+				conflicts = append(conflicts, Conflict{
+					Index: len(out),
+					Len:   len(ha.lines),
+					Base:  base[start:end],
+					A:     ha.lines,
+					B:     hb.lines,
+				})
+				out = append(out, ha.lines...)
+			}
+			pos = end
+			ia++
+			ib++
+		}
+	}
+	emitBaseThrough(len(base))
+
+	return out, conflicts
+}
+
+// RenderConflicts renders merged as a text document, replacing each
+// Conflict's span with standard diff3-style `<<<<<<<`/`|||||||`/
+// `=======`/`>>>>>>>` markers around its a, base, and b versions, so the
+// result is directly editable and recognized by editors. Non-conflicting
+// content is passed through render (or printed as-is when render is
+// nil) and joined with newlines.
+func RenderConflicts(merged []string, conflicts []Conflict, render func(string) string) string {
+	if render == nil {
+		render = func(s string) string { return s }
+	}
+
+	var lines []string
+	pos := 0
+	for _, c := range conflicts {
+		for _, line := range merged[pos:c.Index] {
+			lines = append(lines, render(line))
+		}
+		lines = append(lines, "<<<<<<< A")
+		for _, line := range c.A {
+			lines = append(lines, render(line))
+		}
+		lines = append(lines, "||||||| base")
+		for _, line := range c.Base {
+			lines = append(lines, render(line))
+		}
+		lines = append(lines, "=======")
+		for _, line := range c.B {
+			lines = append(lines, render(line))
+		}
+		lines = append(lines, ">>>>>>> B")
+		pos = c.Index + c.Len
+	}
+	for _, line := range merged[pos:] {
+		lines = append(lines, render(line))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,79 @@
+package diff
+
+import "testing"
+
+func linesEqualSlice(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMerge3AppliesNonOverlappingChangesFromBothSides(t *testing.T) {
+	base := []string{"1", "2", "3"}
+	a := []string{"1", "A", "3"}
+	b := []string{"1", "2", "3", "4"}
+
+	merged, conflicts := Merge3(base, a, b)
+	if len(conflicts) != 0 {
+		t.Fatalf("conflicts = %v, want none", conflicts)
+	}
+	linesEqualSlice(t, merged, []string{"1", "A", "3", "4"})
+}
+
+func TestMerge3FlagsOverlappingChangesAsConflict(t *testing.T) {
+	base := []string{"1", "2", "3"}
+	a := []string{"1", "A", "3"}
+	b := []string{"1", "B", "3"}
+
+	merged, conflicts := Merge3(base, a, b)
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %v, want exactly 1", conflicts)
+	}
+	c := conflicts[0]
+	linesEqualSlice(t, c.Base, []string{"2"})
+	linesEqualSlice(t, c.A, []string{"A"})
+	linesEqualSlice(t, c.B, []string{"B"})
+	if merged[c.Index] != "A" {
+		t.Fatalf("merged[Index] = %q, want the a-side version at the conflict site", merged[c.Index])
+	}
+}
+
+func TestMerge3IdenticalChangeOnBothSidesIsNotAConflict(t *testing.T) {
+	base := []string{"1", "2", "3"}
+	a := []string{"1", "same", "3"}
+	b := []string{"1", "same", "3"}
+
+	merged, conflicts := Merge3(base, a, b)
+	if len(conflicts) != 0 {
+		t.Fatalf("conflicts = %v, want none for an identical change on both sides", conflicts)
+	}
+	linesEqualSlice(t, merged, []string{"1", "same", "3"})
+}
+
+func TestRenderConflictsConflictFreeMergeHasNoMarkers(t *testing.T) {
+	merged := []string{"1", "A", "3", "4"}
+	got := RenderConflicts(merged, nil, nil)
+	want := "1\nA\n3\n4\n"
+	if got != want {
+		t.Fatalf("RenderConflicts = %q, want %q", got, want)
+	}
+}
+
+func TestRenderConflictsEmitsDiff3Markers(t *testing.T) {
+	base := []string{"1", "2", "3"}
+	a := []string{"1", "A", "3"}
+	b := []string{"1", "B", "3"}
+	merged, conflicts := Merge3(base, a, b)
+
+	got := RenderConflicts(merged, conflicts, nil)
+	want := "1\n<<<<<<< A\nA\n||||||| base\n2\n=======\nB\n>>>>>>> B\n3\n"
+	if got != want {
+		t.Fatalf("RenderConflicts = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package diff
+
+import "fmt"
+
+// NormalizeBlocks merges adjacent blocks that share the same Op, so
+// callers who build or transform a []DiffBlock by hand (e.g. after
+// move-detection or other post-processing) get back the same
+// "no adjacent same-kind blocks" invariant Compare itself produces.
+func NormalizeBlocks(blocks []DiffBlock) []DiffBlock {
+	var out []DiffBlock
+	for _, blk := range blocks {
+		if n := len(out); n > 0 && out[n-1].Op == blk.Op {
+			out[n-1].Lines = append(out[n-1].Lines, blk.Lines...)
+			continue
+		}
+		out = append(out, DiffBlock{Op: blk.Op, Lines: append([]string(nil), blk.Lines...)})
+	}
+	return out
+}
+
+// ValidateBlocks returns an error if blocks violates an invariant that
+// the rest of this package relies on: every block has at least one
+// line, and no two adjacent blocks share the same Op.
+func ValidateBlocks(blocks []DiffBlock) error {
+	for i, blk := range blocks {
+		if len(blk.Lines) == 0 {
+			return fmt.Errorf("diff: block %d has no lines", i)
+		}
+		if i > 0 && blocks[i-1].Op == blk.Op {
+			return fmt.Errorf("diff: block %d and %d are adjacent with the same op %v", i-1, i, blk.Op)
+		}
+	}
+	return nil
+}
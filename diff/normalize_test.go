@@ -0,0 +1,35 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeBlocksMergesAdjacentSameKind(t *testing.T) {
+	in := []DiffBlock{
+		{Op: Equal, Lines: []string{"a"}},
+		{Op: Equal, Lines: []string{"b"}},
+		{Op: Delete, Lines: []string{"c"}},
+	}
+	got := NormalizeBlocks(in)
+	want := []DiffBlock{
+		{Op: Equal, Lines: []string{"a", "b"}},
+		{Op: Delete, Lines: []string{"c"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeBlocks = %v, want %v", got, want)
+	}
+}
+
+func TestValidateBlocksRejectsMalformedInput(t *testing.T) {
+	if err := ValidateBlocks([]DiffBlock{{Op: Equal, Lines: nil}}); err == nil {
+		t.Fatal("expected error for empty block")
+	}
+	adjacent := []DiffBlock{{Op: Equal, Lines: []string{"a"}}, {Op: Equal, Lines: []string{"b"}}}
+	if err := ValidateBlocks(adjacent); err == nil {
+		t.Fatal("expected error for adjacent same-kind blocks")
+	}
+	if err := ValidateBlocks(NormalizeBlocks(adjacent)); err != nil {
+		t.Fatalf("normalized blocks should validate: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelThreshold is the combined input size below which
+// computeLCSParallel falls back to the sequential computeLCS instead of
+// spawning a goroutine: below this size the overhead of a goroutine and
+// its own Workspace outweighs any saving.
+const parallelThreshold = 2000
+
+// parallelCompare is the opt-in, concurrent counterpart to Compare,
+// selected by CompareOptions{Parallel: true}. Results are identical to
+// Compare: diffFindMidSnakes is deterministic, so splitting the
+// recursion across goroutines changes nothing but where the work runs,
+// never what it computes.
+func parallelCompare(a, b []string) []DiffBlock {
+	budget := int32(runtime.GOMAXPROCS(0))
+	return buildBlocks(computeLCSParallel(a, b, &budget))
+}
+
+// computeLCSParallel mirrors computeLCS's recursion, but for
+// sub-problems at or above parallelThreshold it runs the two
+// post-middle-snake recursions — which never touch each other's input —
+// concurrently in separate goroutines, each with its own Workspace,
+// as long as budget still allows another goroutine. budget starts at
+// GOMAXPROCS and is adjusted with atomic ops since goroutines spawned
+// from one call may themselves spawn more.
+func computeLCSParallel(a, b []string, budget *int32) []editOp {
+	n, m := len(a), len(b)
+	switch {
+	case n == 0 && m == 0:
+		return nil
+	case n == 0:
+		ops := make([]editOp, m)
+		for i, line := range b {
+			ops[i] = editOp{op: Insert, line: line}
+		}
+		return ops
+	case m == 0:
+		ops := make([]editOp, n)
+		for i, line := range a {
+			ops[i] = editOp{op: Delete, line: line}
+		}
+		return ops
+	}
+
+	if n+m < parallelThreshold || !tryAcquire(budget) {
+		return computeLCS(a, b, &Workspace{})
+	}
+
+	x1, y1, x2, y2 := diffFindMidSnakes(a, b, &Workspace{})
+
+	var left []editOp
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer release(budget)
+		left = computeLCSParallel(a[:x1], b[:y1], budget)
+	}()
+	right := computeLCSParallel(a[x2:], b[y2:], budget)
+	wg.Wait()
+
+	ops := make([]editOp, 0, len(left)+(x2-x1)+len(right))
+	ops = append(ops, left...)
+	for i := x1; i < x2; i++ {
+		ops = append(ops, editOp{op: Equal, line: a[i]})
+	}
+	ops = append(ops, right...)
+	return ops
+}
+
+// tryAcquire claims one unit of budget, reporting false without
+// blocking if none is left.
+func tryAcquire(budget *int32) bool {
+	for {
+		cur := atomic.LoadInt32(budget)
+		if cur <= 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(budget, cur, cur-1) {
+			return true
+		}
+	}
+}
+
+// release returns one unit of budget previously claimed by tryAcquire.
+func release(budget *int32) {
+	atomic.AddInt32(budget, 1)
+}
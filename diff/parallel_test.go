@@ -0,0 +1,80 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCompareWithOptionsParallelMatchesCompareOnSmallInput(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"b", "d", "e"}
+
+	got := CompareWithOptions(a, b, CompareOptions{Parallel: true})
+	want := Compare(a, b)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CompareWithOptions(Parallel) = %v, want %v", got, want)
+	}
+}
+
+func TestCompareWithOptionsParallelMatchesCompareOnLargeInput(t *testing.T) {
+	a := make([]string, 0, 6000)
+	b := make([]string, 0, 6000)
+	for i := 0; i < 3000; i++ {
+		a = append(a, fmt.Sprintf("line %d", i))
+		b = append(b, fmt.Sprintf("line %d", i))
+	}
+	// Interleave a handful of changes so the recursion actually splits
+	// into independent sub-problems rather than being one long snake.
+	a[500] = "changed-a-500"
+	b[1500] = "changed-b-1500"
+	a[2500] = "changed-a-2500"
+
+	got := CompareWithOptions(a, b, CompareOptions{Parallel: true})
+	want := Compare(a, b)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CompareWithOptions(Parallel) diverged from Compare on large input")
+	}
+}
+
+func TestCompareWithOptionsIgnoresParallelWhenWeightSet(t *testing.T) {
+	a := []string{"x", "low"}
+	b := []string{"low", "x"}
+	weight := func(line string) int {
+		if line == "x" {
+			return 10
+		}
+		return 1
+	}
+
+	got := CompareWithOptions(a, b, CompareOptions{Weight: weight, Parallel: true})
+	want := weightedCompare(a, b, weight)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CompareWithOptions(Weight+Parallel) = %v, want weightedCompare result %v", got, want)
+	}
+}
+
+func BenchmarkCompareParallelVsSequential(b *testing.B) {
+	lines := func(n int) []string {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = fmt.Sprintf("line %d", i)
+		}
+		return out
+	}
+	a := lines(4000)
+	c := lines(4000)
+	c[1000] = "changed"
+	c[3000] = "changed"
+
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Compare(a, c)
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			CompareWithOptions(a, c, CompareOptions{Parallel: true})
+		}
+	})
+}
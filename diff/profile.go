@@ -0,0 +1,73 @@
+package diff
+
+// ChangeProfile buckets the source ("a") positions blocks were computed
+// against into fixed-size windows, and reports the fraction of each
+// window's elements that changed (0 = fully unchanged, 1 = fully
+// changed), for rendering a change-density minimap. It's derived
+// entirely from blocks' own Equal/Delete ranges, so no separate copy of
+// a is needed.
+//
+// Insertions have no position in a; each is attributed to the nearest
+// adjacent source position, so it still registers in that window's
+// density instead of being silently dropped. A final partial window
+// (when the source length doesn't divide evenly by windowSize) is
+// averaged over its own, smaller size. A windowSize larger than the
+// source produces a single-element profile.
+func ChangeProfile(blocks []DiffBlock, windowSize int) []float64 {
+	if windowSize <= 0 {
+		return nil
+	}
+
+	var total int
+	for _, blk := range blocks {
+		if blk.Op != Insert {
+			total += len(blk.Lines)
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	changed := make([]bool, total)
+	pos := 0
+	for _, blk := range blocks {
+		switch blk.Op {
+		case Equal:
+			pos += len(blk.Lines)
+		case Delete:
+			for range blk.Lines {
+				changed[pos] = true
+				pos++
+			}
+		case Insert:
+			if len(blk.Lines) == 0 {
+				continue
+			}
+			mark := pos - 1
+			if mark < 0 {
+				mark = pos
+			}
+			if mark < len(changed) {
+				changed[mark] = true
+			}
+		}
+	}
+
+	windows := (total + windowSize - 1) / windowSize
+	profile := make([]float64, windows)
+	for w := 0; w < windows; w++ {
+		start := w * windowSize
+		end := start + windowSize
+		if end > total {
+			end = total
+		}
+		var count int
+		for i := start; i < end; i++ {
+			if changed[i] {
+				count++
+			}
+		}
+		profile[w] = float64(count) / float64(end-start)
+	}
+	return profile
+}
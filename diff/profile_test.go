@@ -0,0 +1,35 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangeProfileConcentratedAtBothEnds(t *testing.T) {
+	a := []string{"x", "x", "1", "2", "3", "4", "5", "6", "x", "x"}
+	b := []string{"1", "2", "3", "4", "5", "6"}
+
+	blocks := Compare(a, b)
+	got := ChangeProfile(blocks, 2)
+	want := []float64{1, 0, 0, 0, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ChangeProfile = %v, want %v", got, want)
+	}
+}
+
+func TestChangeProfileWindowLargerThanInput(t *testing.T) {
+	blocks := Compare([]string{"a", "b"}, []string{"a", "x"})
+	got := ChangeProfile(blocks, 100)
+	if len(got) != 1 || got[0] != 0.5 {
+		t.Fatalf("ChangeProfile = %v, want a single 0.5 window", got)
+	}
+}
+
+func TestChangeProfilePartialFinalWindow(t *testing.T) {
+	blocks := Compare([]string{"a", "b", "c"}, []string{"a", "b", "x"})
+	got := ChangeProfile(blocks, 2)
+	want := []float64{0, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ChangeProfile = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,22 @@
+package diff
+
+import "strings"
+
+// DiffLines compares a and b (already split into lines) and renders the
+// result as a unified diff with context lines of context around each
+// change. It's the single canonical rendering path for callers that
+// already have line slices, so they don't pay for re-splitting strings
+// the way DiffText must.
+func DiffLines(a, b []string, context int) string {
+	return Unified(Compare(a, b), context)
+}
+
+// DiffText is like DiffLines but takes whole-text input, splitting each
+// side into lines (via strings.SplitAfter, so each line keeps its own
+// line ending and a difference in line endings alone counts as a
+// change) before comparing and rendering through the same path as
+// DiffLines. That renderer still ends every output line with its own
+// newline, so a kept ending shows up as an extra blank line.
+func DiffText(a, b string, context int) string {
+	return DiffLines(strings.SplitAfter(a, "\n"), strings.SplitAfter(b, "\n"), context)
+}
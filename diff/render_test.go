@@ -0,0 +1,21 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffLinesAndDiffTextAgree(t *testing.T) {
+	a := []string{"one\n", "two\n", "three\n"}
+	b := []string{"one\n", "deux\n", "three\n"}
+
+	lines := DiffLines(a, b, 1)
+	text := DiffText(strings.Join(a, ""), strings.Join(b, ""), 1)
+
+	if lines != text {
+		t.Fatalf("DiffLines and DiffText disagree:\n%s\n---\n%s", lines, text)
+	}
+	if !strings.Contains(lines, "-two") || !strings.Contains(lines, "+deux") {
+		t.Fatalf("rendered diff missing expected change:\n%s", lines)
+	}
+}
@@ -0,0 +1,67 @@
+package diff
+
+import (
+	"strings"
+)
+
+// padDisplay pads s with trailing spaces so its DisplayWidth (not its
+// byte or rune count) reaches width, leaving s unchanged if it's
+// already at or beyond that width. This is what keeps side-by-side
+// columns aligned once a line contains wide characters.
+func padDisplay(s string, width int) string {
+	if n := width - DisplayWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// SideBySide renders blocks as two columns of width columns each,
+// separated by " | ", with Delete lines on the left, Insert lines on
+// the right, and Equal lines repeated on both sides. columns bounds
+// each side's width in display columns; lines longer than that are
+// left untruncated, since clipping a diff's content would hide the
+// very thing it's meant to show.
+func SideBySide(blocks []DiffBlock, columns int) string {
+	return SideBySideWithOptions(blocks, columns, SideBySideOptions{})
+}
+
+// SideBySideOptions configures a SideBySideWithOptions call.
+type SideBySideOptions struct {
+	// TabWidth, if positive, expands tabs to that many spaces for
+	// display only, the same way UnifiedOptions.TabWidth does. The
+	// default (0) leaves tabs as-is.
+	TabWidth int
+}
+
+// SideBySideWithOptions is like SideBySide but takes a SideBySideOptions
+// for renderers that also need e.g. TabWidth.
+func SideBySideWithOptions(blocks []DiffBlock, columns int, opts SideBySideOptions) string {
+	display := func(s string) string { return ExpandTabs(s, opts.TabWidth) }
+
+	var buf strings.Builder
+	for _, blk := range blocks {
+		switch blk.Op {
+		case Equal:
+			for _, line := range blk.Lines {
+				buf.WriteString(padDisplay(display(line), columns))
+				buf.WriteString(" | ")
+				buf.WriteString(display(line))
+				buf.WriteByte('\n')
+			}
+		case Delete:
+			for _, line := range blk.Lines {
+				buf.WriteString(padDisplay(display(line), columns))
+				buf.WriteString(" | ")
+				buf.WriteByte('\n')
+			}
+		case Insert:
+			for _, line := range blk.Lines {
+				buf.WriteString(padDisplay("", columns))
+				buf.WriteString(" | ")
+				buf.WriteString(display(line))
+				buf.WriteByte('\n')
+			}
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,29 @@
+package diff
+
+// Stats counts the number of blocks and lines of each kind in a diff,
+// a quick summary for callers that want "how big is this change"
+// without walking the blocks themselves.
+type Stats struct {
+	EqualBlocks, DeleteBlocks, InsertBlocks int
+	EqualLines, DeleteLines, InsertLines    int
+}
+
+// Count tabulates blocks into a Stats. An empty or nil diff returns the
+// zero Stats.
+func Count(blocks []DiffBlock) Stats {
+	var stats Stats
+	for _, block := range blocks {
+		switch block.Op {
+		case Equal:
+			stats.EqualBlocks++
+			stats.EqualLines += len(block.Lines)
+		case Delete:
+			stats.DeleteBlocks++
+			stats.DeleteLines += len(block.Lines)
+		case Insert:
+			stats.InsertBlocks++
+			stats.InsertLines += len(block.Lines)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,27 @@
+package diff
+
+import "testing"
+
+func TestCountEmptyDiff(t *testing.T) {
+	if stats := Count(nil); stats != (Stats{}) {
+		t.Fatalf("Count(nil) = %+v, want zero value", stats)
+	}
+}
+
+func TestCountTabulatesEachKind(t *testing.T) {
+	blocks := []DiffBlock{
+		{Op: Equal, Lines: []string{"a", "b"}},
+		{Op: Delete, Lines: []string{"c"}},
+		{Op: Insert, Lines: []string{"d", "e", "f"}},
+		{Op: Equal, Lines: []string{"g"}},
+	}
+	stats := Count(blocks)
+	want := Stats{
+		EqualBlocks: 2, EqualLines: 3,
+		DeleteBlocks: 1, DeleteLines: 1,
+		InsertBlocks: 1, InsertLines: 3,
+	}
+	if stats != want {
+		t.Fatalf("Count(blocks) = %+v, want %+v", stats, want)
+	}
+}
@@ -0,0 +1,51 @@
+package diff
+
+import "strings"
+
+// Suggestion is a GitHub-style suggested-change block: replace source
+// lines [StartLine, EndLine] (1-indexed, inclusive) with Replacement. A
+// pure insertion has an empty range (EndLine == StartLine-1) at the
+// point it was inserted; a pure deletion has an empty Replacement.
+type Suggestion struct {
+	StartLine, EndLine int
+	Replacement        string
+}
+
+// Suggestions converts blocks (as produced by Compare) into one
+// Suggestion per contiguous changed region, so a code-review bot can
+// post them as inline suggested changes. Unchanged (Equal) regions
+// produce no suggestions. Lines are expected not to carry their own
+// trailing newline (the usual convention elsewhere in this package);
+// Replacement joins the destination lines of each region with "\n".
+func Suggestions(blocks []DiffBlock) []Suggestion {
+	var suggestions []Suggestion
+	srcLine := 1 // 1-indexed position in the source (a) sequence
+
+	i := 0
+	for i < len(blocks) {
+		if blocks[i].Op == Equal {
+			srcLine += len(blocks[i].Lines)
+			i++
+			continue
+		}
+
+		start := srcLine
+		var deleted, inserted []string
+		for i < len(blocks) && blocks[i].Op != Equal {
+			if blocks[i].Op == Delete {
+				deleted = append(deleted, blocks[i].Lines...)
+			} else {
+				inserted = append(inserted, blocks[i].Lines...)
+			}
+			i++
+		}
+		srcLine += len(deleted)
+
+		suggestions = append(suggestions, Suggestion{
+			StartLine:   start,
+			EndLine:     start + len(deleted) - 1,
+			Replacement: strings.Join(inserted, "\n"),
+		})
+	}
+	return suggestions
+}
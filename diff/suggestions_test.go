@@ -0,0 +1,61 @@
+package diff
+
+import "testing"
+
+func TestSuggestionsInsertOnly(t *testing.T) {
+	a := []string{"1", "2"}
+	b := []string{"1", "new", "2"}
+	suggestions := Suggestions(Compare(a, b))
+
+	if len(suggestions) != 1 {
+		t.Fatalf("Suggestions = %v, want exactly 1", suggestions)
+	}
+	s := suggestions[0]
+	if s.StartLine != 2 || s.EndLine != 1 {
+		t.Fatalf("insertion range = [%d,%d], want a zero-width range at line 2", s.StartLine, s.EndLine)
+	}
+	if s.Replacement != "new" {
+		t.Fatalf("Replacement = %q, want %q", s.Replacement, "new")
+	}
+}
+
+func TestSuggestionsDeleteOnly(t *testing.T) {
+	a := []string{"1", "gone", "2"}
+	b := []string{"1", "2"}
+	suggestions := Suggestions(Compare(a, b))
+
+	if len(suggestions) != 1 {
+		t.Fatalf("Suggestions = %v, want exactly 1", suggestions)
+	}
+	s := suggestions[0]
+	if s.StartLine != 2 || s.EndLine != 2 {
+		t.Fatalf("deletion range = [%d,%d], want [2,2]", s.StartLine, s.EndLine)
+	}
+	if s.Replacement != "" {
+		t.Fatalf("Replacement = %q, want empty for a pure deletion", s.Replacement)
+	}
+}
+
+func TestSuggestionsReplaceRegion(t *testing.T) {
+	a := []string{"1", "old", "2"}
+	b := []string{"1", "new", "2"}
+	suggestions := Suggestions(Compare(a, b))
+
+	if len(suggestions) != 1 {
+		t.Fatalf("Suggestions = %v, want exactly 1", suggestions)
+	}
+	s := suggestions[0]
+	if s.StartLine != 2 || s.EndLine != 2 {
+		t.Fatalf("replace range = [%d,%d], want [2,2]", s.StartLine, s.EndLine)
+	}
+	if s.Replacement != "new" {
+		t.Fatalf("Replacement = %q, want %q", s.Replacement, "new")
+	}
+}
+
+func TestSuggestionsNoChangesIsEmpty(t *testing.T) {
+	a := []string{"1", "2"}
+	if got := Suggestions(Compare(a, a)); len(got) != 0 {
+		t.Fatalf("Suggestions = %v, want none for identical input", got)
+	}
+}
@@ -0,0 +1,28 @@
+package diff
+
+import "strings"
+
+// ExpandTabs replaces each tab in s with enough spaces to reach the
+// next tab stop every tabWidth columns, using DisplayWidth to track the
+// current column so wide characters are accounted for too. A tabWidth
+// of 0 or less returns s unchanged, so callers can make expansion
+// opt-in without a branch at the call site.
+func ExpandTabs(s string, tabWidth int) string {
+	if tabWidth <= 0 || !strings.Contains(s, "\t") {
+		return s
+	}
+
+	var buf strings.Builder
+	column := 0
+	for _, r := range s {
+		if r == '\t' {
+			spaces := tabWidth - column%tabWidth
+			buf.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+			continue
+		}
+		buf.WriteRune(r)
+		column += runeWidth(r)
+	}
+	return buf.String()
+}
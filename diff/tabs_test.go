@@ -0,0 +1,50 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandTabsZeroWidthLeavesTabsAlone(t *testing.T) {
+	if got := ExpandTabs("a\tb", 0); got != "a\tb" {
+		t.Fatalf("ExpandTabs(.., 0) = %q, want unchanged", got)
+	}
+}
+
+func TestExpandTabsAlignsToTabStops(t *testing.T) {
+	if got := ExpandTabs("a\tb", 4); got != "a   b" {
+		t.Fatalf("ExpandTabs = %q, want %q", got, "a   b")
+	}
+	if got := ExpandTabs("\tb", 4); got != "    b" {
+		t.Fatalf("ExpandTabs = %q, want %q", got, "    b")
+	}
+}
+
+func TestWriteUnifiedWithOptionsExpandsTabsForDisplayOnly(t *testing.T) {
+	a := []string{"a\tone"}
+	b := []string{"a\ttwo"}
+	blocks := Compare(a, b)
+
+	var buf strings.Builder
+	if err := WriteUnifiedWithOptions(&buf, blocks, 0, UnifiedOptions{TabWidth: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "\t") {
+		t.Fatalf("expected tabs expanded in display output:\n%s", buf.String())
+	}
+
+	// Comparison itself must still have used the raw, unexpanded
+	// content: a tab-vs-spaces difference is a real change.
+	tabVsSpaces := Compare([]string{"a\tone"}, []string{"a   one"})
+	if NoChanges(tabVsSpaces) {
+		t.Fatal("expected a tab-vs-spaces difference to be detected, want a change")
+	}
+}
+
+func TestSideBySideWithOptionsExpandsTabs(t *testing.T) {
+	blocks := Compare([]string{"a\tb"}, []string{"a\tb"})
+	out := SideBySideWithOptions(blocks, 10, SideBySideOptions{TabWidth: 4})
+	if strings.Contains(out, "\t") {
+		t.Fatalf("expected tabs expanded in display output:\n%s", out)
+	}
+}
@@ -0,0 +1,69 @@
+package diff
+
+import "fmt"
+
+// Text renders blocks as prefixed lines ("+" insert, "-" delete, " "
+// equal), with no collapsing of unchanged runs. It's the plain-text,
+// non-streaming counterpart to Unified for callers that want the whole
+// diff as a slice of lines rather than a hunked string.
+func Text(blocks []DiffBlock) []string {
+	return TextContext(blocks, -1)
+}
+
+// TextContext renders blocks the same way as Text, but collapses any
+// run of unchanged lines longer than 2*context down to just the
+// context lines at each edge, replacing the elided middle with a
+// single "@@ N lines unchanged @@" marker. A negative context disables
+// collapsing, matching Text.
+func TextContext(blocks []DiffBlock, context int) []string {
+	lines := flattenUnified(blocks)
+	var out []string
+
+	if context < 0 {
+		for _, l := range lines {
+			out = append(out, textPrefix(l.op)+l.line)
+		}
+		return out
+	}
+
+	for i := 0; i < len(lines); {
+		if lines[i].op != Equal {
+			out = append(out, textPrefix(lines[i].op)+lines[i].line)
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(lines) && lines[i].op == Equal {
+			i++
+		}
+		run := lines[start:i]
+
+		if len(run) <= 2*context {
+			for _, l := range run {
+				out = append(out, " "+l.line)
+			}
+			continue
+		}
+
+		for _, l := range run[:context] {
+			out = append(out, " "+l.line)
+		}
+		out = append(out, fmt.Sprintf("@@ %d lines unchanged @@", len(run)-2*context))
+		for _, l := range run[len(run)-context:] {
+			out = append(out, " "+l.line)
+		}
+	}
+	return out
+}
+
+func textPrefix(op Op) string {
+	switch op {
+	case Delete:
+		return "-"
+	case Insert:
+		return "+"
+	default:
+		return " "
+	}
+}
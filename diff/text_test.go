@@ -0,0 +1,36 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTextShowsAllLines(t *testing.T) {
+	blocks := Compare([]string{"a", "b", "c"}, []string{"a", "x", "c"})
+	got := Text(blocks)
+	want := []string{" a", "-b", "+x", " c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Text = %v, want %v", got, want)
+	}
+}
+
+func TestTextContextCollapsesLongEqualRuns(t *testing.T) {
+	a := []string{"1", "2", "3", "4", "5", "6", "7", "x", "9"}
+	b := []string{"1", "2", "3", "4", "5", "6", "7", "y", "9"}
+	blocks := Compare(a, b)
+	got := TextContext(blocks, 1)
+
+	// The leading run (1..7) is longer than 2*context, so it collapses
+	// to its edge context lines plus an elision marker.
+	want := []string{
+		" 1",
+		"@@ 5 lines unchanged @@",
+		" 7",
+		"-x",
+		"+y",
+		" 9",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TextContext = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package diff
+
+// TruncateRunes truncates s to at most max runes, appending ellipsis
+// when truncation happens, with the ellipsis itself counted against
+// max. It never splits a multi-byte rune, unlike truncating by byte
+// length.
+func TruncateRunes(s string, max int, ellipsis string) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	budget := max - len([]rune(ellipsis))
+	if budget < 0 {
+		budget = 0
+	}
+	return string(runes[:budget]) + ellipsis
+}
+
+// TruncateWidth is like TruncateRunes but budgets by DisplayWidth
+// instead of rune count, so a line packed with wide CJK characters is
+// cut at the same terminal column as a plain-ASCII one.
+func TruncateWidth(s string, max int, ellipsis string) string {
+	if DisplayWidth(s) <= max {
+		return s
+	}
+
+	budget := max - DisplayWidth(ellipsis)
+	if budget < 0 {
+		budget = 0
+	}
+
+	var out []rune
+	width := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if width+w > budget {
+			break
+		}
+		out = append(out, r)
+		width += w
+	}
+	return string(out) + ellipsis
+}
@@ -0,0 +1,48 @@
+package diff
+
+import "testing"
+
+func TestTruncateRunesNoOpWhenShortEnough(t *testing.T) {
+	if got := TruncateRunes("hello", 10, "..."); got != "hello" {
+		t.Fatalf("TruncateRunes = %q, want %q", got, "hello")
+	}
+}
+
+func TestTruncateRunesCountsEllipsisAgainstBudget(t *testing.T) {
+	got := TruncateRunes("hello world", 8, "...")
+	if got != "hello..." {
+		t.Fatalf("TruncateRunes = %q, want %q", got, "hello...")
+	}
+}
+
+func TestTruncateRunesMultibyteSafe(t *testing.T) {
+	// "日本語" is 3 runes but 9 bytes; truncating by byte length would
+	// split a rune and produce invalid UTF-8.
+	got := TruncateRunes("日本語です", 4, "…")
+	if got != "日本語…" {
+		t.Fatalf("TruncateRunes = %q, want %q", got, "日本語…")
+	}
+}
+
+func TestTruncateRunesCombiningMarkCountsAsOwnRune(t *testing.T) {
+	s := "éééé" // e + combining acute, four times
+	got := TruncateRunes(s, 4, "")
+	if got != "éé" {
+		t.Fatalf("TruncateRunes = %q, want %q", got, "éé")
+	}
+}
+
+func TestTruncateWidthBudgetsByDisplayColumns(t *testing.T) {
+	// Each CJK char is 2 columns wide, so only 2 fit in a budget of 5
+	// once the 1-column ellipsis is subtracted.
+	got := TruncateWidth("日本語です", 5, "…")
+	if got != "日本…" {
+		t.Fatalf("TruncateWidth = %q, want %q", got, "日本…")
+	}
+}
+
+func TestTruncateWidthNoOpWhenShortEnough(t *testing.T) {
+	if got := TruncateWidth("hi", 10, "..."); got != "hi" {
+		t.Fatalf("TruncateWidth = %q, want %q", got, "hi")
+	}
+}
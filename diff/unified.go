@@ -0,0 +1,221 @@
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// unifiedLine is one line of a flattened diff, with its 1-indexed line
+// number in whichever of a/b it belongs to (0 for the side it's absent
+// from).
+type unifiedLine struct {
+	op    Op
+	line  string
+	aLine int
+	bLine int
+}
+
+func flattenUnified(blocks []DiffBlock) []unifiedLine {
+	return flattenUnifiedWithOffset(blocks, 0)
+}
+
+// flattenUnifiedWithOffset is like flattenUnified but starts numbering
+// at offset+1 instead of 1, for diffs of a region extracted from a
+// larger file where the caller wants line numbers relative to that
+// original file.
+func flattenUnifiedWithOffset(blocks []DiffBlock, offset int) []unifiedLine {
+	var out []unifiedLine
+	a, b := offset+1, offset+1
+	for _, blk := range blocks {
+		for _, line := range blk.Lines {
+			switch blk.Op {
+			case Equal:
+				out = append(out, unifiedLine{Equal, line, a, b})
+				a++
+				b++
+			case Delete:
+				out = append(out, unifiedLine{Delete, line, a, 0})
+				a++
+			case Insert:
+				out = append(out, unifiedLine{Insert, line, 0, b})
+				b++
+			}
+		}
+	}
+	return out
+}
+
+// Unified renders blocks as a unified diff with the given number of
+// context lines around each change, returning the result as a string.
+func Unified(blocks []DiffBlock, context int) string {
+	var buf strings.Builder
+	_ = WriteUnified(&buf, blocks, context, false)
+	return buf.String()
+}
+
+// WriteUnified writes blocks as a unified diff to w, with context lines
+// of unchanged text around each change, flushing hunk by hunk so memory
+// stays bounded on large diffs. When color is true, added/removed lines
+// are wrapped in ANSI color codes.
+func WriteUnified(w io.Writer, blocks []DiffBlock, context int, color bool) error {
+	return WriteUnifiedWithOptions(w, blocks, context, UnifiedOptions{Color: color})
+}
+
+// UnifiedOptions configures a WriteUnifiedWithOptions call.
+type UnifiedOptions struct {
+	// Color wraps added/removed lines in ANSI color codes.
+	Color bool
+	// TabWidth, if positive, expands tabs to that many spaces for
+	// display only, so mixed tab/space indentation lines up in a
+	// terminal. Comparison is unaffected: blocks were already computed
+	// from the raw, unexpanded content, so a tab-vs-spaces difference
+	// is still detected as a change. The default (0) leaves tabs as-is.
+	TabWidth int
+	// LineOffset shifts every hunk header and gutter line number by
+	// this many lines, for diffing a region extracted from a larger
+	// file (see bootstrap's marker-region feature) while still
+	// reporting line numbers relative to the original file rather than
+	// the extracted slice. The default (0) numbers from 1, as today.
+	LineOffset int
+	// WrapWidth, if positive, soft-wraps a content line wider than this
+	// many display columns (see DisplayWidth) across several output
+	// lines instead of letting it run on, with continuation lines
+	// indented under the gutter marker (a space in place of +/-) so the
+	// diff's change structure stays legible in a narrow terminal. The
+	// default (0) never wraps, matching historical behavior. Callers
+	// wanting this sized to the terminal must measure it themselves;
+	// this package does no TTY detection.
+	WrapWidth int
+}
+
+// WriteUnifiedWithOptions is like WriteUnified but takes a full
+// UnifiedOptions instead of a single color flag, for renderers that
+// also need e.g. TabWidth.
+func WriteUnifiedWithOptions(w io.Writer, blocks []DiffBlock, context int, opts UnifiedOptions) error {
+	lines := flattenUnifiedWithOffset(blocks, opts.LineOffset)
+	if opts.TabWidth > 0 {
+		for i := range lines {
+			lines[i].line = ExpandTabs(lines[i].line, opts.TabWidth)
+		}
+	}
+	bw := bufio.NewWriter(w)
+
+	changed := make([]bool, len(lines))
+	for i, l := range lines {
+		changed[i] = l.op != Equal
+	}
+
+	// Group contiguous (within 2*context) changes into hunks.
+	i := 0
+	for i < len(lines) {
+		if !changed[i] {
+			i++
+			continue
+		}
+
+		start := i
+		for start > 0 && i-start < context {
+			start--
+		}
+		end := i
+		for end < len(lines) {
+			// Extend the hunk through this change, merging in the next
+			// change if at most 2*context equal lines separate them;
+			// otherwise close it after context lines of trailing text.
+			next := end
+			for next < len(lines) && changed[next] {
+				next++
+			}
+			end = next
+			gapEnd := end
+			for gapEnd < len(lines) && gapEnd-end < 2*context && !changed[gapEnd] {
+				gapEnd++
+			}
+			if gapEnd < len(lines) && changed[gapEnd] {
+				end = gapEnd
+				continue
+			}
+			end = min(end+context, len(lines))
+			break
+		}
+
+		if err := writeHunk(bw, lines[start:end], opts.Color, opts.WrapWidth); err != nil {
+			return err
+		}
+		i = end
+	}
+
+	return bw.Flush()
+}
+
+func writeHunk(bw *bufio.Writer, lines []unifiedLine, color bool, wrapWidth int) error {
+	var aStart, bStart int
+	var aLen, bLen int
+	for _, l := range lines {
+		switch l.op {
+		case Equal:
+			if aStart == 0 {
+				aStart = l.aLine
+			}
+			if bStart == 0 {
+				bStart = l.bLine
+			}
+			aLen++
+			bLen++
+		case Delete:
+			if aStart == 0 {
+				aStart = l.aLine
+			}
+			aLen++
+		case Insert:
+			if bStart == 0 {
+				bStart = l.bLine
+			}
+			bLen++
+		}
+	}
+
+	if _, err := fmt.Fprintf(bw, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen); err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		prefix, colorCode := " ", ""
+		switch l.op {
+		case Delete:
+			prefix, colorCode = "-", colorRed
+		case Insert:
+			prefix, colorCode = "+", colorGreen
+		}
+
+		chunks := []string{l.line}
+		if wrapWidth > 0 {
+			// -1 for the gutter marker column every chunk is printed
+			// with.
+			budget := wrapWidth - 1
+			if budget < 1 {
+				budget = 1
+			}
+			chunks = WrapWidth(l.line, budget)
+		}
+
+		for i, chunk := range chunks {
+			linePrefix := prefix
+			if i > 0 {
+				linePrefix = " "
+			}
+			var err error
+			if color && colorCode != "" {
+				_, err = fmt.Fprintf(bw, "%s%s%s%s\n", colorCode, linePrefix, chunk, colorReset)
+			} else {
+				_, err = fmt.Fprintf(bw, "%s%s\n", linePrefix, chunk)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
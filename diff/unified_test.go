@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedShowsChanges(t *testing.T) {
+	a := []string{"one", "two", "three"}
+	b := []string{"one", "TWO", "three"}
+	out := Unified(Compare(a, b), 1)
+
+	if !strings.Contains(out, "-two") || !strings.Contains(out, "+TWO") {
+		t.Fatalf("Unified output missing expected lines:\n%s", out)
+	}
+	if !strings.Contains(out, "@@") {
+		t.Fatalf("Unified output missing hunk header:\n%s", out)
+	}
+}
+
+func TestWriteUnifiedColor(t *testing.T) {
+	a := []string{"x"}
+	b := []string{"y"}
+	var buf strings.Builder
+	if err := WriteUnified(&buf, Compare(a, b), 0, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), colorRed) || !strings.Contains(buf.String(), colorGreen) {
+		t.Fatalf("expected ANSI color codes in output:\n%s", buf.String())
+	}
+}
+
+func TestWriteUnifiedWithOptionsLineOffsetShiftsHunkHeaders(t *testing.T) {
+	a := []string{"one", "two", "three"}
+	b := []string{"one", "TWO", "three"}
+	var buf strings.Builder
+	err := WriteUnifiedWithOptions(&buf, Compare(a, b), 0, UnifiedOptions{LineOffset: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "@@ -102,1 +102,1 @@") {
+		t.Fatalf("hunk header not shifted by LineOffset:\n%s", buf.String())
+	}
+}
+
+func TestWriteUnifiedWithOptionsWrapWidthWrapsLongLines(t *testing.T) {
+	a := []string{"short"}
+	b := []string{"this line is much longer than the configured wrap width"}
+	var buf strings.Builder
+	err := WriteUnifiedWithOptions(&buf, Compare(a, b), 0, UnifiedOptions{WrapWidth: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	for _, l := range lines {
+		if strings.HasPrefix(l, "@@") {
+			continue
+		}
+		if DisplayWidth(l) > 20 {
+			t.Fatalf("line %q exceeds WrapWidth 20 (width %d)", l, DisplayWidth(l))
+		}
+	}
+
+	var sawContinuation bool
+	for _, l := range lines {
+		if strings.HasPrefix(l, " ") && !strings.HasPrefix(l, "@@") {
+			sawContinuation = true
+		}
+	}
+	if !sawContinuation {
+		t.Fatalf("expected a space-indented continuation line:\n%s", buf.String())
+	}
+}
+
+func TestWriteUnifiedWithOptionsWrapWidthZeroDisablesWrapping(t *testing.T) {
+	a := []string{"short"}
+	longLine := strings.Repeat("x", 200)
+	b := []string{longLine}
+	var buf strings.Builder
+	err := WriteUnifiedWithOptions(&buf, Compare(a, b), 0, UnifiedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "+"+longLine) {
+		t.Fatalf("expected the long line unwrapped:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,68 @@
+package diff
+
+// weightedLCSOps aligns a and b like computeLCS, but among alignments
+// that share the same (maximal) number of matched lines, prefers the
+// one maximizing the total weight of matched lines, using weight
+// (treated as constant 1 when nil, matching computeLCS's behavior).
+// This is an O(len(a)*len(b)) dynamic program rather than Compare's
+// linear-space Myers algorithm, trading memory efficiency on huge
+// inputs for the tie-breaking Compare has no way to express — meant for
+// the short, weight-sensitive sequences used by token diffs, not
+// Compare's large-file case.
+func weightedLCSOps(a, b []string, weight func(line string) int) []editOp {
+	if weight == nil {
+		weight = func(string) int { return 1 }
+	}
+
+	n, m := len(a), len(b)
+	length := make([][]int, n+1)
+	totalWeight := make([][]int, n+1)
+	for i := range length {
+		length[i] = make([]int, m+1)
+		totalWeight[i] = make([]int, m+1)
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			bestLen, bestWeight := length[i-1][j], totalWeight[i-1][j]
+			if l, w := length[i][j-1], totalWeight[i][j-1]; l > bestLen || (l == bestLen && w > bestWeight) {
+				bestLen, bestWeight = l, w
+			}
+			if a[i-1] == b[j-1] {
+				if l, w := length[i-1][j-1]+1, totalWeight[i-1][j-1]+weight(a[i-1]); l > bestLen || (l == bestLen && w > bestWeight) {
+					bestLen, bestWeight = l, w
+				}
+			}
+			length[i][j], totalWeight[i][j] = bestLen, bestWeight
+		}
+	}
+
+	var ops []editOp
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && a[i-1] == b[j-1] &&
+			length[i][j] == length[i-1][j-1]+1 && totalWeight[i][j] == totalWeight[i-1][j-1]+weight(a[i-1]):
+			ops = append(ops, editOp{op: Equal, line: a[i-1]})
+			i--
+			j--
+		case j > 0 && (i == 0 || (length[i][j] == length[i][j-1] && totalWeight[i][j] == totalWeight[i][j-1])):
+			ops = append(ops, editOp{op: Insert, line: b[j-1]})
+			j--
+		default:
+			ops = append(ops, editOp{op: Delete, line: a[i-1]})
+			i--
+		}
+	}
+
+	for l, r := 0, len(ops)-1; l < r; l, r = l+1, r-1 {
+		ops[l], ops[r] = ops[r], ops[l]
+	}
+	return ops
+}
+
+// weightedCompare is Compare's counterpart for weighted tie-breaking;
+// see weightedLCSOps.
+func weightedCompare(a, b []string, weight func(line string) int) []DiffBlock {
+	return buildBlocks(weightedLCSOps(a, b, weight))
+}
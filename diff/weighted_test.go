@@ -0,0 +1,53 @@
+package diff
+
+import "testing"
+
+// TestCompareWithOptionsWeightPrefersHigherWeightMatch constructs a case
+// with two equal-length alignments of a shared "x" and relies on Weight
+// to prefer keeping the higher-weight one matched (Equal) rather than
+// deleted/inserted around.
+func TestCompareWithOptionsWeightPrefersHigherWeightMatch(t *testing.T) {
+	a := []string{"x", "low"}
+	b := []string{"low", "x"}
+
+	weight := func(line string) int {
+		if line == "x" {
+			return 10
+		}
+		return 1
+	}
+
+	blocks := CompareWithOptions(a, b, CompareOptions{Weight: weight})
+
+	var matchedX, matchedLow bool
+	for _, blk := range blocks {
+		if blk.Op != Equal {
+			continue
+		}
+		for _, l := range blk.Lines {
+			if l == "x" {
+				matchedX = true
+			}
+			if l == "low" {
+				matchedLow = true
+			}
+		}
+	}
+
+	if !matchedX {
+		t.Fatalf("expected the high-weight line \"x\" to be matched as Equal, got %v", blocks)
+	}
+	if matchedLow {
+		t.Fatalf("expected only one of \"x\"/\"low\" to match given both can't align simultaneously, got %v", blocks)
+	}
+}
+
+func TestCompareWithOptionsNilWeightMatchesCompare(t *testing.T) {
+	a := []string{"x", "y"}
+	b := []string{"y", "x"}
+	got := CompareWithOptions(a, b, CompareOptions{})
+	want := Compare(a, b)
+	if len(got) != len(want) {
+		t.Fatalf("CompareWithOptions with nil Weight = %v, want %v", got, want)
+	}
+}
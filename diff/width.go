@@ -0,0 +1,83 @@
+package diff
+
+import "unicode"
+
+// wideRanges lists the Unicode blocks whose runes occupy two terminal
+// columns: CJK ideographs and their punctuation, the Kana and Hangul
+// scripts, fullwidth forms, and the common emoji blocks. This mirrors
+// East Asian Width "Wide"/"Fullwidth" in spirit without pulling in a
+// full Unicode-width table.
+var wideRanges = []unicode.RangeTable{
+	{R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115F, Stride: 1}, // Hangul Jamo
+		{Lo: 0x2E80, Hi: 0x303E, Stride: 1}, // CJK Radicals, Kangxi, CJK Symbols and Punctuation
+		{Lo: 0x3041, Hi: 0x33FF, Stride: 1}, // Hiragana..CJK Compatibility
+		{Lo: 0x3400, Hi: 0x4DBF, Stride: 1}, // CJK Unified Ideographs Extension A
+		{Lo: 0x4E00, Hi: 0x9FFF, Stride: 1}, // CJK Unified Ideographs
+		{Lo: 0xA000, Hi: 0xA4CF, Stride: 1}, // Yi
+		{Lo: 0xAC00, Hi: 0xD7A3, Stride: 1}, // Hangul Syllables
+		{Lo: 0xF900, Hi: 0xFAFF, Stride: 1}, // CJK Compatibility Ideographs
+		{Lo: 0xFF00, Hi: 0xFF60, Stride: 1}, // Fullwidth Forms
+		{Lo: 0xFFE0, Hi: 0xFFE6, Stride: 1}, // Fullwidth Signs
+	}},
+	{R32: []unicode.Range32{
+		{Lo: 0x1F300, Hi: 0x1FAFF, Stride: 1}, // Misc Symbols/Pictographs/Emoticons/Emoji
+		{Lo: 0x20000, Hi: 0x3FFFD, Stride: 1}, // CJK Unified Ideographs Extension B and beyond
+	}},
+}
+
+func runeWidth(r rune) int {
+	if r == 0 {
+		return 0
+	}
+	if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Cf, r) {
+		return 0
+	}
+	for _, table := range wideRanges {
+		if unicode.Is(&table, r) {
+			return 2
+		}
+	}
+	return 1
+}
+
+// WrapWidth splits s into chunks that each fit within width display
+// columns, never splitting a multi-byte rune. The last chunk may be
+// shorter. A non-positive width, or one s already fits within, returns
+// s as a single unsplit chunk.
+func WrapWidth(s string, width int) []string {
+	if width <= 0 || DisplayWidth(s) <= width {
+		return []string{s}
+	}
+
+	var chunks []string
+	var cur []rune
+	curWidth := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if curWidth+w > width && len(cur) > 0 {
+			chunks = append(chunks, string(cur))
+			cur = nil
+			curWidth = 0
+		}
+		cur = append(cur, r)
+		curWidth += w
+	}
+	if len(cur) > 0 {
+		chunks = append(chunks, string(cur))
+	}
+	return chunks
+}
+
+// DisplayWidth returns how many terminal columns s occupies, counting
+// East-Asian wide characters (CJK ideographs, Hangul, fullwidth forms,
+// common emoji) as 2 columns and zero-width runes (combining marks,
+// format characters) as 0, instead of the 1-column-per-rune assumption
+// that misaligns tables once such characters appear.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
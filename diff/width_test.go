@@ -0,0 +1,112 @@
+package diff
+
+import "testing"
+
+func TestDisplayWidthASCII(t *testing.T) {
+	if w := DisplayWidth("hello"); w != 5 {
+		t.Fatalf("DisplayWidth(%q) = %d, want 5", "hello", w)
+	}
+}
+
+func TestDisplayWidthCJK(t *testing.T) {
+	if w := DisplayWidth("日本語"); w != 6 {
+		t.Fatalf("DisplayWidth = %d, want 6", w)
+	}
+}
+
+func TestDisplayWidthCombiningMarkIsZero(t *testing.T) {
+	// "e" followed by a combining acute accent (U+0301).
+	s := "é"
+	if w := DisplayWidth(s); w != 1 {
+		t.Fatalf("DisplayWidth(%q) = %d, want 1", s, w)
+	}
+}
+
+func TestDisplayWidthMixedAsciiCjkEmoji(t *testing.T) {
+	// "ab" is 2 columns, "日" (CJK) is 2, "🎉" (emoji) is 2.
+	s := "ab日🎉"
+	if w := DisplayWidth(s); w != 2+2+2 {
+		t.Fatalf("DisplayWidth(%q) = %d, want %d", s, w, 2+2+2)
+	}
+}
+
+func TestWrapWidthSplitsAtTheGivenWidth(t *testing.T) {
+	chunks := WrapWidth("abcdefghij", 4)
+	want := []string{"abcd", "efgh", "ij"}
+	if len(chunks) != len(want) {
+		t.Fatalf("WrapWidth = %v, want %v", chunks, want)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Fatalf("WrapWidth = %v, want %v", chunks, want)
+		}
+	}
+}
+
+func TestWrapWidthLeavesShortStringsUnsplit(t *testing.T) {
+	chunks := WrapWidth("short", 80)
+	if len(chunks) != 1 || chunks[0] != "short" {
+		t.Fatalf("WrapWidth(short) = %v, want a single unsplit chunk", chunks)
+	}
+}
+
+func TestWrapWidthDoesNotSplitAWideRune(t *testing.T) {
+	// Each CJK rune is 2 columns wide; a width of 3 can't fit two of
+	// them, so each chunk must hold exactly one rune rather than
+	// splitting one in half.
+	chunks := WrapWidth("日本語", 3)
+	want := []string{"日", "本", "語"}
+	if len(chunks) != len(want) {
+		t.Fatalf("WrapWidth = %v, want %v", chunks, want)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Fatalf("WrapWidth = %v, want %v", chunks, want)
+		}
+	}
+}
+
+func TestWrapWidthNonPositiveWidthReturnsUnsplit(t *testing.T) {
+	chunks := WrapWidth("anything", 0)
+	if len(chunks) != 1 || chunks[0] != "anything" {
+		t.Fatalf("WrapWidth with width 0 = %v, want a single unsplit chunk", chunks)
+	}
+}
+
+func TestSideBySideAlignsWideColumns(t *testing.T) {
+	blocks := Compare([]string{"日本語"}, []string{"abc"})
+	out := SideBySide(blocks, 10)
+	for _, line := range splitLinesKeepEmpty(out) {
+		if line == "" {
+			continue
+		}
+		idx := indexOf(line, " | ")
+		if idx < 0 {
+			t.Fatalf("line %q has no column separator", line)
+		}
+		if w := DisplayWidth(line[:idx]); w != 10 {
+			t.Fatalf("left column of line %q has display width %d, want 10", line, w)
+		}
+	}
+}
+
+func splitLinesKeepEmpty(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	return lines
+}
+
+func indexOf(s, sub string) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if s[i:i+len(sub)] == sub {
+			return i
+		}
+	}
+	return -1
+}